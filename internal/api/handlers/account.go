@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/emper0r/val-store-server/internal/models"
+	"github.com/emper0r/val-store-server/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+// AccountHandler 处理玩家账号信息相关请求
+type AccountHandler struct {
+	accountService *services.AccountService
+}
+
+// NewAccountHandler 创建新的账号处理器
+func NewAccountHandler(accountService *services.AccountService) *AccountHandler {
+	return &AccountHandler{
+		accountService: accountService,
+	}
+}
+
+// GetAccountXP 获取账号等级和经验
+func (h *AccountHandler) GetAccountXP(c *gin.Context) {
+	session, ok := requireSession(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.accountService.GetAccountXP(session, requestLanguage(c))
+	if err != nil {
+		c.JSON(http.StatusBadGateway, models.APIError{
+			Status:  http.StatusBadGateway,
+			Message: "获取账号经验失败",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APISuccess{
+		Status:  http.StatusOK,
+		Message: "获取账号经验成功",
+		Data:    response,
+	})
+}
+
+// GetMMR 获取段位和各赛季的竞技模式战绩
+func (h *AccountHandler) GetMMR(c *gin.Context) {
+	session, ok := requireSession(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.accountService.GetMMR(session, requestLanguage(c))
+	if err != nil {
+		c.JSON(http.StatusBadGateway, models.APIError{
+			Status:  http.StatusBadGateway,
+			Message: "获取段位信息失败",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APISuccess{
+		Status:  http.StatusOK,
+		Message: "获取段位信息成功",
+		Data:    response,
+	})
+}
+
+// RegisterRoutes 注册账号相关路由，所有路由都需要认证
+func (h *AccountHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	account := router.Group("/account", authMiddleware)
+	{
+		account.GET("/xp", h.GetAccountXP)
+		account.GET("/mmr", h.GetMMR)
+	}
+}
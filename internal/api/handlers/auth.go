@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/emper0r/val-store-server/internal/models"
@@ -53,6 +54,90 @@ func (h *AuthHandler) LoginWithCookies(c *gin.Context) {
 	})
 }
 
+// LoginWithPassword 处理账号密码登录请求
+func (h *AuthHandler) LoginWithPassword(c *gin.Context) {
+	var request models.PasswordLoginRequest
+
+	// 绑定JSON数据到结构体
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIError{
+			Status:  http.StatusBadRequest,
+			Message: "无效的请求数据",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	response, challenge, err := h.authService.LoginWithPassword(request)
+	writePasswordLoginResult(c, response, challenge, err)
+}
+
+// SubmitMFA 处理邮箱验证码提交请求
+func (h *AuthHandler) SubmitMFA(c *gin.Context) {
+	var request models.MFALoginRequest
+
+	// 绑定JSON数据到结构体
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIError{
+			Status:  http.StatusBadRequest,
+			Message: "无效的请求数据",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	response, challenge, err := h.authService.SubmitMFA(request)
+	writePasswordLoginResult(c, response, challenge, err)
+}
+
+// writePasswordLoginResult 返回账号密码登录的结果，需要用户继续操作时返回202和挑战信息
+func writePasswordLoginResult(c *gin.Context, response *models.UserTokensResponse, challenge *models.LoginChallenge, err error) {
+	if err != nil {
+		status := http.StatusBadGateway
+		switch {
+		case errors.Is(err, services.ErrInvalidCredentials),
+			errors.Is(err, services.ErrInvalidMFACode),
+			errors.Is(err, services.ErrMFAAttemptsExceeded):
+			status = http.StatusUnauthorized
+		case errors.Is(err, services.ErrLoginExpired):
+			status = http.StatusNotFound
+		case errors.Is(err, services.ErrLoginStepMismatch):
+			status = http.StatusConflict
+		case errors.Is(err, services.ErrLoginRateLimited),
+			errors.Is(err, services.ErrTooManyPendingLogins):
+			status = http.StatusTooManyRequests
+		case errors.Is(err, services.ErrLoginInternal):
+			status = http.StatusInternalServerError
+		}
+		c.JSON(status, models.APIError{
+			Status:  status,
+			Message: "登录失败",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if challenge != nil {
+		message := "需要输入邮箱验证码"
+		if challenge.Type == models.LoginChallengeCaptcha {
+			message = "需要完成人机验证"
+		}
+		c.JSON(http.StatusAccepted, models.APISuccess{
+			Status:  http.StatusAccepted,
+			Message: message,
+			Data:    challenge,
+		})
+		return
+	}
+
+	// 返回登录成功响应
+	c.JSON(http.StatusOK, models.APISuccess{
+		Status:  http.StatusOK,
+		Message: "登录成功",
+		Data:    response,
+	})
+}
+
 // Ping 简单的健康检查端点
 func (h *AuthHandler) Ping(c *gin.Context) {
 	c.JSON(http.StatusOK, models.APISuccess{
@@ -66,6 +151,8 @@ func (h *AuthHandler) RegisterRoutes(router *gin.RouterGroup) {
 	auth := router.Group("/auth")
 	{
 		auth.POST("/login/cookies", h.LoginWithCookies)
+		auth.POST("/login/password", h.LoginWithPassword)
+		auth.POST("/login/mfa", h.SubmitMFA)
 		auth.GET("/ping", h.Ping)
 	}
 }
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/emper0r/val-store-server/internal/models"
+	"github.com/emper0r/val-store-server/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+// ContractHandler 处理通行证、合约和任务相关请求
+type ContractHandler struct {
+	contractService *services.ContractService
+}
+
+// NewContractHandler 创建新的合约处理器
+func NewContractHandler(contractService *services.ContractService) *ContractHandler {
+	return &ContractHandler{
+		contractService: contractService,
+	}
+}
+
+// GetContracts 获取通行证、当前激活的合约和特工合约的进度
+func (h *ContractHandler) GetContracts(c *gin.Context) {
+	session, ok := requireSession(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.contractService.GetContracts(session, requestLanguage(c))
+	if err != nil {
+		writeContractError(c, "获取合约信息失败", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APISuccess{
+		Status:  http.StatusOK,
+		Message: "获取合约信息成功",
+		Data:    response,
+	})
+}
+
+// GetContractProjection 预测完成当前通行证需要的每日经验和比赛数
+func (h *ContractHandler) GetContractProjection(c *gin.Context) {
+	session, ok := requireSession(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.contractService.GetContractProjection(session, requestLanguage(c))
+	if err != nil {
+		writeContractError(c, "预测通行证进度失败", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APISuccess{
+		Status:  http.StatusOK,
+		Message: "预测通行证进度成功",
+		Data:    response,
+	})
+}
+
+// GetMissions 获取每日任务和每周任务
+func (h *ContractHandler) GetMissions(c *gin.Context) {
+	session, ok := requireSession(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.contractService.GetMissions(session, requestLanguage(c))
+	if err != nil {
+		writeContractError(c, "获取任务失败", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APISuccess{
+		Status:  http.StatusOK,
+		Message: "获取任务成功",
+		Data:    response,
+	})
+}
+
+// writeContractError 根据错误类型返回对应的状态码
+func writeContractError(c *gin.Context, message string, err error) {
+	status := http.StatusBadGateway
+	switch {
+	case errors.Is(err, services.ErrCatalogUnavailable):
+		status = http.StatusServiceUnavailable
+	case errors.Is(err, services.ErrNoBattlePass):
+		status = http.StatusNotFound
+	}
+
+	c.JSON(status, models.APIError{
+		Status:  status,
+		Message: message,
+		Error:   err.Error(),
+	})
+}
+
+// RegisterRoutes 注册合约相关路由，所有路由都需要认证
+func (h *ContractHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	contracts := router.Group("/contracts", authMiddleware)
+	{
+		contracts.GET("", h.GetContracts)
+		contracts.GET("/projection", h.GetContractProjection)
+	}
+
+	router.GET("/missions", authMiddleware, h.GetMissions)
+}
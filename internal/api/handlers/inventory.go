@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/emper0r/val-store-server/internal/models"
+	"github.com/emper0r/val-store-server/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+// InventoryHandler 处理玩家库存相关请求
+type InventoryHandler struct {
+	inventoryService *services.InventoryService
+}
+
+// NewInventoryHandler 创建新的库存处理器
+func NewInventoryHandler(inventoryService *services.InventoryService) *InventoryHandler {
+	return &InventoryHandler{
+		inventoryService: inventoryService,
+	}
+}
+
+// GetInventory 获取玩家拥有的物品，通过?type=指定类型，默认为皮肤
+func (h *InventoryHandler) GetInventory(c *gin.Context) {
+	session, ok := requireSession(c)
+	if !ok {
+		return
+	}
+
+	inventoryType := c.DefaultQuery("type", "skins")
+	if _, ok := models.InventoryTypes[inventoryType]; !ok {
+		c.JSON(http.StatusBadRequest, models.APIError{
+			Status:  http.StatusBadRequest,
+			Message: "无效的请求数据",
+			Error:   "type只能是skins、chromas、buddies、sprays、cards、titles或agents",
+		})
+		return
+	}
+
+	response, err := h.inventoryService.GetInventory(session, inventoryType, requestLanguage(c))
+	if err != nil {
+		c.JSON(http.StatusBadGateway, models.APIError{
+			Status:  http.StatusBadGateway,
+			Message: "获取库存失败",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APISuccess{
+		Status:  http.StatusOK,
+		Message: "获取库存成功",
+		Data:    response,
+	})
+}
+
+// RegisterRoutes 注册库存相关路由，所有路由都需要认证
+func (h *InventoryHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	inventory := router.Group("/inventory", authMiddleware)
+	{
+		inventory.GET("", h.GetInventory)
+	}
+}
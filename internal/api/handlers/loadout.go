@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/emper0r/val-store-server/internal/models"
+	"github.com/emper0r/val-store-server/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+// LoadoutHandler 处理玩家配置相关请求
+type LoadoutHandler struct {
+	loadoutService *services.LoadoutService
+}
+
+// NewLoadoutHandler 创建新的配置处理器
+func NewLoadoutHandler(loadoutService *services.LoadoutService) *LoadoutHandler {
+	return &LoadoutHandler{
+		loadoutService: loadoutService,
+	}
+}
+
+// GetLoadout 获取当前配置
+func (h *LoadoutHandler) GetLoadout(c *gin.Context) {
+	session, ok := requireSession(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.loadoutService.GetLoadout(session, requestLanguage(c))
+	if err != nil {
+		c.JSON(http.StatusBadGateway, models.APIError{
+			Status:  http.StatusBadGateway,
+			Message: "获取配置失败",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APISuccess{
+		Status:  http.StatusOK,
+		Message: "获取配置成功",
+		Data:    response,
+	})
+}
+
+// UpdateLoadout 修改配置
+func (h *LoadoutHandler) UpdateLoadout(c *gin.Context) {
+	session, ok := requireSession(c)
+	if !ok {
+		return
+	}
+
+	var request models.LoadoutUpdateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIError{
+			Status:  http.StatusBadRequest,
+			Message: "无效的请求数据",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.loadoutService.UpdateLoadout(session, &request, requestLanguage(c))
+	if err != nil {
+		writeLoadoutError(c, "修改配置失败", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APISuccess{
+		Status:  http.StatusOK,
+		Message: "修改配置成功",
+		Data:    response,
+	})
+}
+
+// ListPresets 列出保存的配置预设
+func (h *LoadoutHandler) ListPresets(c *gin.Context) {
+	presets, err := h.loadoutService.ListPresets(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIError{
+			Status:  http.StatusInternalServerError,
+			Message: "获取配置预设失败",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APISuccess{
+		Status:  http.StatusOK,
+		Message: "获取配置预设成功",
+		Data:    presets,
+	})
+}
+
+// SavePreset 保存配置预设
+func (h *LoadoutHandler) SavePreset(c *gin.Context) {
+	session, ok := requireSession(c)
+	if !ok {
+		return
+	}
+
+	var request models.SavePresetRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIError{
+			Status:  http.StatusBadRequest,
+			Message: "无效的请求数据",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	preset, err := h.loadoutService.SavePreset(session, &request)
+	if err != nil {
+		writeLoadoutError(c, "保存配置预设失败", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APISuccess{
+		Status:  http.StatusOK,
+		Message: "保存配置预设成功",
+		Data:    preset,
+	})
+}
+
+// DeletePreset 删除配置预设
+func (h *LoadoutHandler) DeletePreset(c *gin.Context) {
+	if err := h.loadoutService.DeletePreset(c.GetString("user_id"), c.Param("name")); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrPresetNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, models.APIError{
+			Status:  status,
+			Message: "删除配置预设失败",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APISuccess{
+		Status:  http.StatusOK,
+		Message: "删除配置预设成功",
+	})
+}
+
+// ApplyPreset 应用配置预设
+func (h *LoadoutHandler) ApplyPreset(c *gin.Context) {
+	session, ok := requireSession(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.loadoutService.ApplyPreset(session, c.Param("name"), requestLanguage(c))
+	if err != nil {
+		writeLoadoutError(c, "应用配置预设失败", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APISuccess{
+		Status:  http.StatusOK,
+		Message: "应用配置预设成功",
+		Data:    response,
+	})
+}
+
+// RandomizeLoadout 为每把武器随机选择已拥有的皮肤，可以只预览或直接应用
+func (h *LoadoutHandler) RandomizeLoadout(c *gin.Context) {
+	session, ok := requireSession(c)
+	if !ok {
+		return
+	}
+
+	// 请求体可以为空，此时预览不受限制的随机结果
+	var request models.RandomizeLoadoutRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, models.APIError{
+				Status:  http.StatusBadRequest,
+				Message: "无效的请求数据",
+				Error:   err.Error(),
+			})
+			return
+		}
+	}
+
+	response, err := h.loadoutService.RandomizeLoadout(session, &request, requestLanguage(c))
+	if err != nil {
+		writeLoadoutError(c, "随机配置失败", err)
+		return
+	}
+
+	message := "随机配置预览成功"
+	if response.Applied {
+		message = "随机配置已应用"
+	}
+	c.JSON(http.StatusOK, models.APISuccess{
+		Status:  http.StatusOK,
+		Message: message,
+		Data:    response,
+	})
+}
+
+// writeLoadoutError 根据错误类型返回对应的状态码
+func writeLoadoutError(c *gin.Context, message string, err error) {
+	status := http.StatusBadGateway
+
+	var validationErr *services.LoadoutValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		status = http.StatusBadRequest
+	case errors.Is(err, services.ErrUnknownTier), errors.Is(err, services.ErrNoRandomCandidates), errors.Is(err, services.ErrInvalidPreset):
+		status = http.StatusBadRequest
+	case errors.Is(err, services.ErrPresetNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, services.ErrCatalogUnavailable):
+		status = http.StatusServiceUnavailable
+	case errors.Is(err, services.ErrSettingsStorage):
+		status = http.StatusInternalServerError
+	}
+
+	c.JSON(status, models.APIError{
+		Status:  status,
+		Message: message,
+		Error:   err.Error(),
+	})
+}
+
+// RegisterRoutes 注册配置相关路由，所有路由都需要认证
+func (h *LoadoutHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	loadout := router.Group("/loadout", authMiddleware)
+	{
+		loadout.GET("", h.GetLoadout)
+		loadout.PUT("", h.UpdateLoadout)
+		loadout.POST("/randomize", h.RandomizeLoadout)
+		loadout.GET("/presets", h.ListPresets)
+		loadout.POST("/presets", h.SavePreset)
+		loadout.DELETE("/presets/:name", h.DeletePreset)
+		loadout.POST("/presets/:name/apply", h.ApplyPreset)
+	}
+}
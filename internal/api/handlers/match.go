@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/emper0r/val-store-server/internal/models"
+	"github.com/emper0r/val-store-server/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+// MatchHandler 处理比赛历史和比赛详情相关请求
+type MatchHandler struct {
+	matchService *services.MatchService
+}
+
+// NewMatchHandler 创建新的比赛处理器
+func NewMatchHandler(matchService *services.MatchService) *MatchHandler {
+	return &MatchHandler{
+		matchService: matchService,
+	}
+}
+
+// GetMatchHistory 获取比赛历史，通过?queue=筛选模式，?start=和?end=分页
+func (h *MatchHandler) GetMatchHistory(c *gin.Context) {
+	session, ok := requireSession(c)
+	if !ok {
+		return
+	}
+
+	start, err := strconv.Atoi(c.DefaultQuery("start", "0"))
+	if err != nil {
+		writeInvalidQuery(c, "start必须是整数")
+		return
+	}
+	end, err := strconv.Atoi(c.DefaultQuery("end", strconv.Itoa(start+services.DefaultMatchPageSize)))
+	if err != nil {
+		writeInvalidQuery(c, "end必须是整数")
+		return
+	}
+
+	response, err := h.matchService.GetMatchHistory(session, c.Query("queue"), start, end, requestLanguage(c))
+	if err != nil {
+		status := http.StatusBadGateway
+		if errors.Is(err, services.ErrInvalidMatchRange) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, models.APIError{
+			Status:  status,
+			Message: "获取比赛历史失败",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APISuccess{
+		Status:  http.StatusOK,
+		Message: "获取比赛历史成功",
+		Data:    response,
+	})
+}
+
+// GetMatch 获取比赛详情的摘要
+func (h *MatchHandler) GetMatch(c *gin.Context) {
+	session, ok := requireSession(c)
+	if !ok {
+		return
+	}
+
+	summary, err := h.matchService.GetMatchSummary(session, c.Param("id"), requestLanguage(c))
+	if err != nil {
+		c.JSON(http.StatusBadGateway, models.APIError{
+			Status:  http.StatusBadGateway,
+			Message: "获取比赛详情失败",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APISuccess{
+		Status:  http.StatusOK,
+		Message: "获取比赛详情成功",
+		Data:    summary,
+	})
+}
+
+// QueryArchive 按地图、特工、小赛季和模式查询存档中的比赛
+func (h *MatchHandler) QueryArchive(c *gin.Context) {
+	var query models.MatchArchiveQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		writeInvalidQuery(c, err.Error())
+		return
+	}
+
+	response, err := h.matchService.QueryArchive(c.GetString("user_id"), &query, requestLanguage(c))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrInvalidArchiveQuery) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, models.APIError{
+			Status:  status,
+			Message: "查询比赛存档失败",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APISuccess{
+		Status:  http.StatusOK,
+		Message: "查询比赛存档成功",
+		Data:    response,
+	})
+}
+
+// SyncArchive 立即同步当前用户的比赛存档
+func (h *MatchHandler) SyncArchive(c *gin.Context) {
+	session, ok := requireSession(c)
+	if !ok {
+		return
+	}
+
+	saved, total, err := h.matchService.SyncMatches(session)
+	if err != nil {
+		c.JSON(http.StatusBadGateway, models.APIError{
+			Status:  http.StatusBadGateway,
+			Message: "同步比赛存档失败",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APISuccess{
+		Status:  http.StatusOK,
+		Message: "同步比赛存档成功",
+		Data:    models.MatchSyncResponse{NewMatches: saved, Total: total},
+	})
+}
+
+// writeInvalidQuery 返回查询参数无效的错误
+func writeInvalidQuery(c *gin.Context, reason string) {
+	c.JSON(http.StatusBadRequest, models.APIError{
+		Status:  http.StatusBadRequest,
+		Message: "无效的请求数据",
+		Error:   reason,
+	})
+}
+
+// RegisterRoutes 注册比赛相关路由，所有路由都需要认证
+func (h *MatchHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	matches := router.Group("/matches", authMiddleware)
+	{
+		matches.GET("", h.GetMatchHistory)
+		matches.GET("/archive", h.QueryArchive)
+		matches.POST("/archive/sync", h.SyncArchive)
+		matches.GET("/:id", h.GetMatch)
+	}
+}
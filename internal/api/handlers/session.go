@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/emper0r/val-store-server/internal/api/middleware"
+	"github.com/emper0r/val-store-server/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// requireSession 获取AuthMiddleware加载的会话，不存在时直接返回401
+func requireSession(c *gin.Context) (*models.UserSession, bool) {
+	session, ok := middleware.GetSession(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, models.APIError{
+			Status:  http.StatusUnauthorized,
+			Message: "未授权",
+			Error:   "缺少用户会话",
+		})
+		return nil, false
+	}
+
+	return session, true
+}
+
+// requestLanguage 获取请求的内容语言，优先使用?lang=参数，其次使用Accept-Language头
+// 返回值由内容目录统一规范化，无法识别时使用默认语言
+func requestLanguage(c *gin.Context) string {
+	if lang := c.Query("lang"); lang != "" {
+		return lang
+	}
+
+	// 只取Accept-Language中的第一个语言，例如 zh-CN,zh;q=0.9 -> zh-CN
+	acceptLanguage := c.GetHeader("Accept-Language")
+	first := strings.SplitN(acceptLanguage, ",", 2)[0]
+	return strings.TrimSpace(strings.SplitN(first, ";", 2)[0])
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/emper0r/val-store-server/internal/models"
+	"github.com/emper0r/val-store-server/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+// StatsHandler 处理基于比赛存档的统计请求
+type StatsHandler struct {
+	matchService *services.MatchService
+}
+
+// NewStatsHandler 创建新的统计处理器
+func NewStatsHandler(matchService *services.MatchService) *StatsHandler {
+	return &StatsHandler{
+		matchService: matchService,
+	}
+}
+
+// GetStats 汇总存档中的比赛，筛选条件与比赛存档查询相同
+func (h *StatsHandler) GetStats(c *gin.Context) {
+	var query models.MatchArchiveQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		writeInvalidQuery(c, err.Error())
+		return
+	}
+
+	response, err := h.matchService.GetStats(c.GetString("user_id"), &query, requestLanguage(c))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrInvalidArchiveQuery) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, models.APIError{
+			Status:  status,
+			Message: "获取统计数据失败",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APISuccess{
+		Status:  http.StatusOK,
+		Message: "获取统计数据成功",
+		Data:    response,
+	})
+}
+
+// GetHeatmap 返回玩家在指定地图上击杀或死亡位置的PNG热力图
+func (h *StatsHandler) GetHeatmap(c *gin.Context) {
+	var query models.HeatmapQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		writeInvalidQuery(c, err.Error())
+		return
+	}
+
+	image, err := h.matchService.RenderHeatmap(c.GetString("user_id"), &query, requestLanguage(c))
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, services.ErrInvalidArchiveQuery):
+			status = http.StatusBadRequest
+		case errors.Is(err, services.ErrCatalogUnavailable):
+			status = http.StatusServiceUnavailable
+		}
+		c.JSON(status, models.APIError{
+			Status:  status,
+			Message: "生成热力图失败",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.Data(http.StatusOK, "image/png", image)
+}
+
+// RegisterRoutes 注册统计相关路由，所有路由都需要认证
+func (h *StatsHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	stats := router.Group("/stats", authMiddleware)
+	{
+		stats.GET("", h.GetStats)
+		stats.GET("/heatmap", h.GetHeatmap)
+	}
+}
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/emper0r/val-store-server/internal/models"
+	"github.com/emper0r/val-store-server/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+// StoreHandler 处理商店相关请求
+type StoreHandler struct {
+	storeService *services.StoreService
+}
+
+// NewStoreHandler 创建新的商店处理器
+func NewStoreHandler(storeService *services.StoreService) *StoreHandler {
+	return &StoreHandler{
+		storeService: storeService,
+	}
+}
+
+// GetDailyStore 获取每日商店
+func (h *StoreHandler) GetDailyStore(c *gin.Context) {
+	session, ok := requireSession(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.storeService.GetDailyStore(session, requestLanguage(c))
+	if err != nil {
+		c.JSON(http.StatusBadGateway, models.APIError{
+			Status:  http.StatusBadGateway,
+			Message: "获取每日商店失败",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APISuccess{
+		Status:  http.StatusOK,
+		Message: "获取每日商店成功",
+		Data:    response,
+	})
+}
+
+// GetNightMarket 获取夜市
+func (h *StoreHandler) GetNightMarket(c *gin.Context) {
+	session, ok := requireSession(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.storeService.GetNightMarket(session, requestLanguage(c))
+	if err != nil {
+		c.JSON(http.StatusBadGateway, models.APIError{
+			Status:  http.StatusBadGateway,
+			Message: "获取夜市失败",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	// 夜市未开放时同样返回200，客户端可以据此显示倒计时
+	message := "获取夜市成功"
+	if !response.Active {
+		message = "夜市当前未开放"
+	}
+
+	c.JSON(http.StatusOK, models.APISuccess{
+		Status:  http.StatusOK,
+		Message: message,
+		Data:    response,
+	})
+}
+
+// GetFeaturedBundles 获取精选捆绑包
+func (h *StoreHandler) GetFeaturedBundles(c *gin.Context) {
+	session, ok := requireSession(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.storeService.GetFeaturedBundles(session, requestLanguage(c))
+	if err != nil {
+		c.JSON(http.StatusBadGateway, models.APIError{
+			Status:  http.StatusBadGateway,
+			Message: "获取捆绑包失败",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APISuccess{
+		Status:  http.StatusOK,
+		Message: "获取捆绑包成功",
+		Data:    response,
+	})
+}
+
+// GetAccessoryStore 获取配件商店
+func (h *StoreHandler) GetAccessoryStore(c *gin.Context) {
+	session, ok := requireSession(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.storeService.GetAccessoryStore(session, requestLanguage(c))
+	if err != nil {
+		c.JSON(http.StatusBadGateway, models.APIError{
+			Status:  http.StatusBadGateway,
+			Message: "获取配件商店失败",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APISuccess{
+		Status:  http.StatusOK,
+		Message: "获取配件商店成功",
+		Data:    response,
+	})
+}
+
+// GetWallet 获取钱包余额
+func (h *StoreHandler) GetWallet(c *gin.Context) {
+	session, ok := requireSession(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.storeService.GetWallet(session)
+	if err != nil {
+		c.JSON(http.StatusBadGateway, models.APIError{
+			Status:  http.StatusBadGateway,
+			Message: "获取钱包失败",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APISuccess{
+		Status:  http.StatusOK,
+		Message: "获取钱包成功",
+		Data:    response,
+	})
+}
+
+// RegisterRoutes 注册商店相关路由，所有路由都需要认证
+func (h *StoreHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	store := router.Group("/store", authMiddleware)
+	{
+		store.GET("/daily", h.GetDailyStore)
+		store.GET("/night-market", h.GetNightMarket)
+		store.GET("/bundles", h.GetFeaturedBundles)
+		store.GET("/accessories", h.GetAccessoryStore)
+		store.GET("/wallet", h.GetWallet)
+	}
+}
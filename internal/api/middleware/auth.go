@@ -9,6 +9,9 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// SessionContextKey 上下文中保存Riot会话的键
+const SessionContextKey = "session"
+
 // AuthMiddleware 创建认证中间件
 func AuthMiddleware(authService *services.AuthService) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -50,10 +53,34 @@ func AuthMiddleware(authService *services.AuthService) gin.HandlerFunc {
 			return
 		}
 
+		// 加载令牌对应的Riot会话
+		session, err := authService.LoadSession(claims)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, models.APIError{
+				Status:  http.StatusUnauthorized,
+				Message: "未授权",
+				Error:   "会话已失效: " + err.Error(),
+			})
+			c.Abort()
+			return
+		}
+
 		// 将用户信息存储在上下文中，供后续处理使用
 		c.Set("user_id", claims.UserID)
 		c.Set("username", claims.Username)
+		c.Set("session_id", claims.ID)
+		c.Set(SessionContextKey, session)
 
 		c.Next()
 	}
 }
+
+// GetSession 从上下文中获取AuthMiddleware加载的Riot会话
+func GetSession(c *gin.Context) (*models.UserSession, bool) {
+	value, exists := c.Get(SessionContextKey)
+	if !exists {
+		return nil, false
+	}
+	session, ok := value.(*models.UserSession)
+	return session, ok
+}
@@ -1,8 +1,13 @@
 package api
 
 import (
+	"context"
+	"strconv"
+	"time"
+
 	"github.com/emper0r/val-store-server/internal/api/handlers"
 	"github.com/emper0r/val-store-server/internal/api/middleware"
+	"github.com/emper0r/val-store-server/internal/config"
 	"github.com/emper0r/val-store-server/internal/repositories"
 	"github.com/emper0r/val-store-server/internal/services"
 	"github.com/gin-gonic/gin"
@@ -19,17 +24,103 @@ func SetupRouter(router *gin.Engine) *gin.Engine {
 		panic(err)
 	}
 
+	sessionStore, err := repositories.NewSessionStore(
+		config.GetEnv("SESSION_STORE", repositories.SessionStoreMemory),
+		config.GetEnv("SESSION_FILE", "data/sessions.json"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	// 按用户保存的设置，例如配置预设
+	settingsStore, err := repositories.NewFileUserSettingsStore(config.GetEnv("USER_SETTINGS_DIR", "data/users"))
+	if err != nil {
+		panic(err)
+	}
+
+	// 比赛存档，永久保存已登录用户的比赛详情
+	matchArchive, err := repositories.NewFileMatchArchive(config.GetEnv("MATCH_ARCHIVE_DIR", "data/matches"))
+	if err != nil {
+		panic(err)
+	}
+	matchSyncInterval, err := time.ParseDuration(config.GetEnv("MATCH_SYNC_INTERVAL", "30m"))
+	if err != nil {
+		panic(err)
+	}
+
+	// 每周任务提醒，距离刷新不足N小时时提醒，为0时不提醒
+	missionReminderHours, err := strconv.Atoi(config.GetEnv("MISSION_REMINDER_HOURS", "0"))
+	if err != nil {
+		panic(err)
+	}
+
+	// 游戏内容目录，按客户端版本和语言缓存在磁盘上
+	contentCatalog := repositories.NewContentCatalog(
+		valorantAPI,
+		config.GetEnv("CONTENT_CACHE_DIR", "data/content"),
+		config.GetEnv("CONTENT_LANGUAGE", repositories.DefaultContentLanguage),
+	)
+
 	// 初始化服务
-	authService := services.NewAuthService(valorantAPI)
+	authService := services.NewAuthService(valorantAPI, sessionStore)
+	storeService := services.NewStoreService(valorantAPI, contentCatalog)
+	inventoryService := services.NewInventoryService(valorantAPI, contentCatalog)
+	loadoutService := services.NewLoadoutService(valorantAPI, contentCatalog, settingsStore)
+	accountService := services.NewAccountService(valorantAPI, contentCatalog)
+	matchService := services.NewMatchService(valorantAPI, contentCatalog, sessionStore, matchArchive)
+	contractService := services.NewContractService(valorantAPI, contentCatalog)
+
+	// 在后台定期使用保存的Cookie刷新即将过期的Riot令牌
+	go authService.StartSessionRefresher(context.Background(), time.Minute)
+	// 在后台定期将已登录用户的新比赛保存到存档，间隔为0时不同步
+	if matchSyncInterval > 0 {
+		go matchService.StartMatchSync(context.Background(), matchSyncInterval)
+	}
+	// 在后台定期检查未完成的每周任务，通过Webhook或日志提醒
+	if missionReminderHours > 0 {
+		missionReminder := services.NewMissionReminder(
+			contractService,
+			sessionStore,
+			repositories.NewNotifier(config.GetEnv("MISSION_REMINDER_WEBHOOK", "")),
+			time.Duration(missionReminderHours)*time.Hour,
+		)
+		go missionReminder.Start(context.Background(), 15*time.Minute)
+	}
+	// 在后台预加载游戏内容，避免第一个请求等待下载
+	go contentCatalog.Preload()
 
 	// 初始化处理器
 	authHandler := handlers.NewAuthHandler(authService)
+	storeHandler := handlers.NewStoreHandler(storeService)
+	inventoryHandler := handlers.NewInventoryHandler(inventoryService)
+	loadoutHandler := handlers.NewLoadoutHandler(loadoutService)
+	accountHandler := handlers.NewAccountHandler(accountService)
+	matchHandler := handlers.NewMatchHandler(matchService)
+	statsHandler := handlers.NewStatsHandler(matchService)
+	contractHandler := handlers.NewContractHandler(contractService)
+
+	// 需要登录的路由使用的认证中间件
+	authMiddleware := middleware.AuthMiddleware(authService)
 
 	// API路由组
 	api := router.Group("/api")
 	{
 		// 注册认证处理器的路由
 		authHandler.RegisterRoutes(api)
+		// 注册商店处理器的路由
+		storeHandler.RegisterRoutes(api, authMiddleware)
+		// 注册库存处理器的路由
+		inventoryHandler.RegisterRoutes(api, authMiddleware)
+		// 注册配置处理器的路由
+		loadoutHandler.RegisterRoutes(api, authMiddleware)
+		// 注册账号处理器的路由
+		accountHandler.RegisterRoutes(api, authMiddleware)
+		// 注册比赛处理器的路由
+		matchHandler.RegisterRoutes(api, authMiddleware)
+		// 注册统计处理器的路由
+		statsHandler.RegisterRoutes(api, authMiddleware)
+		// 注册合约处理器的路由
+		contractHandler.RegisterRoutes(api, authMiddleware)
 	}
 
 	return router
@@ -0,0 +1,141 @@
+package models
+
+import "time"
+
+// XPPerLevel 每个账号等级需要的经验
+const XPPerLevel = 5000
+
+// XPSourceNames 经验来源ID对应的名称
+var XPSourceNames = map[string]string{
+	"time-played":          "游戏时长",
+	"match-win":            "比赛胜利",
+	"first-win-of-the-day": "每日首胜",
+}
+
+// ValorantAccountProgress 账号等级和当前等级内的经验
+type ValorantAccountProgress struct {
+	Level int `json:"Level"`
+	XP    int `json:"XP"`
+}
+
+// ValorantAccountXPResponse Riot账号经验接口的响应
+type ValorantAccountXPResponse struct {
+	Version  int                     `json:"Version"`
+	Subject  string                  `json:"Subject"`
+	Progress ValorantAccountProgress `json:"Progress"`
+	History  []struct {
+		ID            string                  `json:"ID"` // 比赛ID
+		MatchStart    time.Time               `json:"MatchStart"`
+		StartProgress ValorantAccountProgress `json:"StartProgress"`
+		EndProgress   ValorantAccountProgress `json:"EndProgress"`
+		XPDelta       int                     `json:"XPDelta"`
+		XPSources     []struct {
+			ID     string `json:"ID"`
+			Amount int    `json:"Amount"`
+		} `json:"XPSources"`
+	} `json:"History"`
+	LastTimeGrantedFirstWin   time.Time `json:"LastTimeGrantedFirstWin"`
+	NextTimeFirstWinAvailable time.Time `json:"NextTimeFirstWinAvailable"`
+}
+
+// LevelBorderInfo 账号等级边框的图片
+type LevelBorderInfo struct {
+	UUID          string `json:"uuid"`
+	StartingLevel int    `json:"starting_level"`
+	LevelIcon     string `json:"level_icon,omitempty"` // 等级数字的背景
+	CardIcon      string `json:"card_icon,omitempty"`  // 小卡面的边框
+}
+
+// XPSource 单个经验来源
+type XPSource struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Amount int    `json:"amount"`
+}
+
+// XPHistoryEntry 一场比赛获得的经验
+type XPHistoryEntry struct {
+	MatchID    string     `json:"match_id"`
+	MatchStart time.Time  `json:"match_start"`
+	StartLevel int        `json:"start_level"`
+	EndLevel   int        `json:"end_level"`
+	XPDelta    int        `json:"xp_delta"`
+	Sources    []XPSource `json:"sources"`
+}
+
+// AccountXPResponse 账号等级和经验
+type AccountXPResponse struct {
+	Level                 int              `json:"level"`
+	XP                    int              `json:"xp"` // 当前等级内已获得的经验
+	XPPerLevel            int              `json:"xp_per_level"`
+	XPToNextLevel         int              `json:"xp_to_next_level"`
+	Progress              float64          `json:"progress"` // 当前等级的进度，0到1
+	LevelBorder           *LevelBorderInfo `json:"level_border,omitempty"`
+	History               []XPHistoryEntry `json:"history"`
+	LastFirstWin          time.Time        `json:"last_first_win"`
+	NextFirstWinAvailable time.Time        `json:"next_first_win_available"`
+}
+
+// ValorantSeasonalInfo 单个赛季中某个模式的战绩
+type ValorantSeasonalInfo struct {
+	SeasonID                   string `json:"SeasonID"`
+	NumberOfWins               int    `json:"NumberOfWins"`
+	NumberOfWinsWithPlacements int    `json:"NumberOfWinsWithPlacements"`
+	NumberOfGames              int    `json:"NumberOfGames"`
+	CompetitiveTier            int    `json:"CompetitiveTier"`
+	RankedRating               int    `json:"RankedRating"`
+	LeaderboardRank            int    `json:"LeaderboardRank"`
+	GamesNeededForRating       int    `json:"GamesNeededForRating"`
+}
+
+// ValorantMMRResponse Riot段位接口的响应
+type ValorantMMRResponse struct {
+	Subject     string `json:"Subject"`
+	QueueSkills map[string]struct {
+		TotalGamesNeededForRating int                             `json:"TotalGamesNeededForRating"`
+		SeasonalInfoBySeasonID    map[string]ValorantSeasonalInfo `json:"SeasonalInfoBySeasonID"`
+	} `json:"QueueSkills"`
+	LatestCompetitiveUpdate struct {
+		MatchID                  string `json:"MatchID"`
+		SeasonID                 string `json:"SeasonID"`
+		MatchStartTime           int64  `json:"MatchStartTime"` // 毫秒时间戳
+		TierAfterUpdate          int    `json:"TierAfterUpdate"`
+		RankedRatingAfterUpdate  int    `json:"RankedRatingAfterUpdate"`
+		RankedRatingEarned       int    `json:"RankedRatingEarned"`
+		RankedRatingBeforeUpdate int    `json:"RankedRatingBeforeUpdate"`
+		TierBeforeUpdate         int    `json:"TierBeforeUpdate"`
+	} `json:"LatestCompetitiveUpdate"`
+	IsLeaderboardAnonymized bool `json:"IsLeaderboardAnonymized"`
+}
+
+// SeasonMMR 单个赛季的竞技模式战绩
+type SeasonMMR struct {
+	SeasonID        string   `json:"season_id"`
+	SeasonName      string   `json:"season_name,omitempty"`
+	Rank            RankInfo `json:"rank"`
+	RankedRating    int      `json:"ranked_rating"`
+	LeaderboardRank int      `json:"leaderboard_rank,omitempty"`
+	Wins            int      `json:"wins"`
+	Games           int      `json:"games"`
+}
+
+// LatestRankedUpdate 最近一场竞技比赛的排名分变化
+type LatestRankedUpdate struct {
+	MatchID            string    `json:"match_id"`
+	MatchStart         time.Time `json:"match_start"`
+	RankBefore         RankInfo  `json:"rank_before"`
+	RankAfter          RankInfo  `json:"rank_after"`
+	RankedRatingEarned int       `json:"ranked_rating_earned"`
+}
+
+// MMRResponse 玩家当前段位和各赛季战绩
+type MMRResponse struct {
+	CurrentSeasonID      string              `json:"current_season_id,omitempty"`
+	CurrentSeasonName    string              `json:"current_season_name,omitempty"`
+	Rank                 RankInfo            `json:"rank"`
+	RankedRating         int                 `json:"ranked_rating"`
+	LeaderboardRank      int                 `json:"leaderboard_rank,omitempty"` // 未进入排行榜时为空
+	GamesNeededForRating int                 `json:"games_needed_for_rating"`    // 定级前还需要的比赛数
+	LatestUpdate         *LatestRankedUpdate `json:"latest_update,omitempty"`
+	Seasons              []SeasonMMR         `json:"seasons"` // 最近的赛季排在前面
+}
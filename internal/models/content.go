@@ -0,0 +1,222 @@
+package models
+
+import "time"
+
+// 物品类型ID，Riot在商店和权益接口中使用
+const (
+	ItemTypeSkinLevel  = "e7c63390-eda7-46e0-bb7a-a6abdacd2433" // 皮肤（等级）
+	ItemTypeSkinChroma = "3ad1b2b2-acdb-4524-852f-954a76ddae0a" // 皮肤炫彩
+	ItemTypeBuddy      = "dd3bf334-87f3-40bd-b043-682a57a8dc3a" // 枪饰（等级）
+	ItemTypeSpray      = "d5f120f8-ff8c-4aac-92ea-f2b5acbe9475" // 喷漆
+	ItemTypePlayerCard = "3f296c07-64c3-494c-923b-fe692a4fa1bd" // 玩家卡面
+	ItemTypeTitle      = "de7caa6b-adf7-4588-bbd1-143831e786c6" // 玩家称号
+	ItemTypeAgent      = "01bb38e1-da47-4e6a-9b3d-945fe4655707" // 特工
+)
+
+// ContentWeapon valorant-api.com中的武器
+type ContentWeapon struct {
+	UUID            string        `json:"uuid"`
+	DisplayName     string        `json:"displayName"`
+	Category        string        `json:"category"`
+	DefaultSkinUUID string        `json:"defaultSkinUuid"` // 默认皮肤，所有玩家都拥有
+	DisplayIcon     string        `json:"displayIcon"`
+	Skins           []ContentSkin `json:"skins"`
+}
+
+// ContentSkin valorant-api.com中的武器皮肤
+type ContentSkin struct {
+	UUID            string             `json:"uuid"`
+	DisplayName     string             `json:"displayName"`
+	ThemeUUID       string             `json:"themeUuid"`
+	ContentTierUUID string             `json:"contentTierUuid"`
+	DisplayIcon     string             `json:"displayIcon"`
+	Chromas         []ContentChroma    `json:"chromas"`
+	Levels          []ContentSkinLevel `json:"levels"`
+	WeaponUUID      string             `json:"-"` // 所属武器，加载时填充
+}
+
+// ContentSkinLevel 皮肤等级
+type ContentSkinLevel struct {
+	UUID          string `json:"uuid"`
+	DisplayName   string `json:"displayName"`
+	LevelItem     string `json:"levelItem"`
+	DisplayIcon   string `json:"displayIcon"`
+	StreamedVideo string `json:"streamedVideo"`
+	SkinUUID      string `json:"-"` // 所属皮肤，加载时填充
+}
+
+// ContentChroma 皮肤炫彩
+type ContentChroma struct {
+	UUID          string `json:"uuid"`
+	DisplayName   string `json:"displayName"`
+	DisplayIcon   string `json:"displayIcon"`
+	FullRender    string `json:"fullRender"`
+	Swatch        string `json:"swatch"`
+	StreamedVideo string `json:"streamedVideo"`
+	SkinUUID      string `json:"-"` // 所属皮肤，加载时填充
+}
+
+// ContentTier 皮肤品质等级
+type ContentTier struct {
+	UUID           string `json:"uuid"`
+	DisplayName    string `json:"displayName"`
+	DevName        string `json:"devName"`
+	Rank           int    `json:"rank"`
+	HighlightColor string `json:"highlightColor"` // RRGGBBAA格式
+	DisplayIcon    string `json:"displayIcon"`
+}
+
+// ContentBundle 捆绑包
+type ContentBundle struct {
+	UUID               string `json:"uuid"`
+	DisplayName        string `json:"displayName"`
+	DisplayNameSubText string `json:"displayNameSubText"`
+	Description        string `json:"description"`
+	DisplayIcon        string `json:"displayIcon"`
+	DisplayIcon2       string `json:"displayIcon2"`
+	VerticalPromoImage string `json:"verticalPromoImage"`
+}
+
+// ContentBuddy 枪饰
+type ContentBuddy struct {
+	UUID        string              `json:"uuid"`
+	DisplayName string              `json:"displayName"`
+	DisplayIcon string              `json:"displayIcon"`
+	Levels      []ContentBuddyLevel `json:"levels"`
+}
+
+// ContentBuddyLevel 枪饰等级，商店和权益中使用的是等级ID
+type ContentBuddyLevel struct {
+	UUID        string `json:"uuid"`
+	CharmLevel  int    `json:"charmLevel"`
+	DisplayName string `json:"displayName"`
+	DisplayIcon string `json:"displayIcon"`
+	BuddyUUID   string `json:"-"` // 所属枪饰，加载时填充
+}
+
+// ContentSpray 喷漆
+type ContentSpray struct {
+	UUID                string `json:"uuid"`
+	DisplayName         string `json:"displayName"`
+	DisplayIcon         string `json:"displayIcon"`
+	FullTransparentIcon string `json:"fullTransparentIcon"`
+	AnimationGif        string `json:"animationGif"`
+}
+
+// ContentPlayerCard 玩家卡面
+type ContentPlayerCard struct {
+	UUID        string `json:"uuid"`
+	DisplayName string `json:"displayName"`
+	DisplayIcon string `json:"displayIcon"`
+	SmallArt    string `json:"smallArt"`
+	WideArt     string `json:"wideArt"`
+	LargeArt    string `json:"largeArt"`
+}
+
+// ContentPlayerTitle 玩家称号
+type ContentPlayerTitle struct {
+	UUID        string `json:"uuid"`
+	DisplayName string `json:"displayName"`
+	TitleText   string `json:"titleText"`
+}
+
+// ContentAgent 特工
+type ContentAgent struct {
+	UUID         string `json:"uuid"`
+	DisplayName  string `json:"displayName"`
+	DisplayIcon  string `json:"displayIcon"`
+	FullPortrait string `json:"fullPortrait"`
+}
+
+// ContentLevelBorder 账号等级边框，达到startingLevel后使用
+type ContentLevelBorder struct {
+	UUID                      string `json:"uuid"`
+	DisplayName               string `json:"displayName"`
+	StartingLevel             int    `json:"startingLevel"`
+	LevelNumberAppearance     string `json:"levelNumberAppearance"`
+	SmallPlayerCardAppearance string `json:"smallPlayerCardAppearance"`
+}
+
+// ContentSeason 赛季，包括大赛季（Episode）和小赛季（Act）
+type ContentSeason struct {
+	UUID        string    `json:"uuid"`
+	DisplayName string    `json:"displayName"`
+	Type        string    `json:"type"` // EAresSeasonType::Episode或EAresSeasonType::Act
+	StartTime   time.Time `json:"startTime"`
+	EndTime     time.Time `json:"endTime"`
+	ParentUUID  string    `json:"parentUuid"`
+}
+
+// SeasonTypeAct 小赛季的类型
+const SeasonTypeAct = "EAresSeasonType::Act"
+
+// ContentCompetitiveSeason 赛季使用的段位组
+type ContentCompetitiveSeason struct {
+	UUID                 string `json:"uuid"`
+	SeasonUUID           string `json:"seasonUuid"`
+	CompetitiveTiersUUID string `json:"competitiveTiersUuid"`
+}
+
+// ContentCompetitiveTierSet 一组段位，段位图标可能随大赛季变化
+type ContentCompetitiveTierSet struct {
+	UUID  string                   `json:"uuid"`
+	Tiers []ContentCompetitiveTier `json:"tiers"`
+}
+
+// ContentCompetitiveTier 单个段位
+type ContentCompetitiveTier struct {
+	Tier            int    `json:"tier"`
+	TierName        string `json:"tierName"`
+	DivisionName    string `json:"divisionName"`
+	Color           string `json:"color"` // RRGGBBAA格式
+	BackgroundColor string `json:"backgroundColor"`
+	SmallIcon       string `json:"smallIcon"`
+	LargeIcon       string `json:"largeIcon"`
+}
+
+// RankInfo 返回给客户端的段位信息
+type RankInfo struct {
+	Tier      int    `json:"tier"`
+	Name      string `json:"name,omitempty"`
+	Division  string `json:"division,omitempty"`
+	Color     string `json:"color,omitempty"`
+	Icon      string `json:"icon,omitempty"`
+	LargeIcon string `json:"large_icon,omitempty"`
+}
+
+// ContentMap 地图，坐标参数用于将比赛中的坐标转换为小地图上的位置
+type ContentMap struct {
+	UUID         string  `json:"uuid"`
+	DisplayName  string  `json:"displayName"`
+	MapURL       string  `json:"mapUrl"`      // 比赛详情中的mapId
+	DisplayIcon  string  `json:"displayIcon"` // 小地图
+	ListViewIcon string  `json:"listViewIcon"`
+	Splash       string  `json:"splash"`
+	XMultiplier  float64 `json:"xMultiplier"`
+	YMultiplier  float64 `json:"yMultiplier"`
+	XScalarToAdd float64 `json:"xScalarToAdd"`
+	YScalarToAdd float64 `json:"yScalarToAdd"`
+}
+
+// ContentQueue 匹配队列
+type ContentQueue struct {
+	UUID        string `json:"uuid"`
+	QueueID     string `json:"queueId"`
+	DisplayName string `json:"displayName"`
+	DisplayIcon string `json:"displayIcon"`
+}
+
+// TierInfo 返回给客户端的品质信息
+type TierInfo struct {
+	UUID  string `json:"uuid"`
+	Name  string `json:"name"`
+	Color string `json:"color"` // 十六进制颜色，例如#5a9fe2
+	Icon  string `json:"icon,omitempty"`
+}
+
+// ItemInfo 从内容目录解析出的物品展示信息
+type ItemInfo struct {
+	Name string    `json:"name,omitempty"`
+	Icon string    `json:"icon,omitempty"`
+	Tier *TierInfo `json:"tier,omitempty"`
+}
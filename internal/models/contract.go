@@ -0,0 +1,188 @@
+package models
+
+import "time"
+
+// 合约关联的类型
+const (
+	ContractRelationSeason = "Season" // 通行证
+	ContractRelationAgent  = "Agent"  // 特工合约
+	ContractRelationEvent  = "Event"  // 活动通行证
+)
+
+// ContentContract valorant-api.com中的合约
+type ContentContract struct {
+	UUID        string `json:"uuid"`
+	DisplayName string `json:"displayName"`
+	DisplayIcon string `json:"displayIcon"`
+	Content     struct {
+		RelationType string                   `json:"relationType"`
+		RelationUUID string                   `json:"relationUuid"`
+		Chapters     []ContentContractChapter `json:"chapters"`
+	} `json:"content"`
+}
+
+// ContentContractChapter 合约的章节，免费奖励在章节完成时获得
+type ContentContractChapter struct {
+	IsEpilogue  bool                    `json:"isEpilogue"`
+	Levels      []ContentContractLevel  `json:"levels"`
+	FreeRewards []ContentContractReward `json:"freeRewards"`
+}
+
+// ContentContractLevel 合约的单个等级
+type ContentContractLevel struct {
+	Reward ContentContractReward `json:"reward"`
+	XP     int                   `json:"xp"`
+}
+
+// ContentContractReward 合约奖励
+type ContentContractReward struct {
+	Type   string `json:"type"` // EquippableSkinLevel、EquippableCharmLevel、Spray、PlayerCard、Title、Currency、Character等
+	UUID   string `json:"uuid"`
+	Amount int    `json:"amount"`
+}
+
+// ValorantContractsResponse Riot合约接口的响应
+type ValorantContractsResponse struct {
+	Subject   string `json:"Subject"`
+	Contracts []struct {
+		ContractDefinitionID string `json:"ContractDefinitionID"`
+		ContractProgression  struct {
+			TotalProgressionEarned int `json:"TotalProgressionEarned"`
+		} `json:"ContractProgression"`
+		ProgressionLevelReached     int `json:"ProgressionLevelReached"`
+		ProgressionTowardsNextLevel int `json:"ProgressionTowardsNextLevel"`
+	} `json:"Contracts"`
+	ActiveSpecialContract string `json:"ActiveSpecialContract"`
+	Missions              []struct {
+		ID             string         `json:"ID"`
+		Objectives     map[string]int `json:"Objectives"` // 目标ID -> 当前进度
+		Complete       bool           `json:"Complete"`
+		ExpirationTime time.Time      `json:"ExpirationTime"`
+	} `json:"Missions"`
+	MissionMetadata struct {
+		NPECompleted     bool      `json:"NPECompleted"`
+		WeeklyCheckpoint time.Time `json:"WeeklyCheckpoint"`
+		WeeklyRefillTime time.Time `json:"WeeklyRefillTime"`
+	} `json:"MissionMetadata"`
+}
+
+// ContractReward 返回给客户端的合约奖励
+type ContractReward struct {
+	ItemInfo
+	Type   string `json:"type"`
+	ID     string `json:"id"`
+	Amount int    `json:"amount,omitempty"`
+}
+
+// ContractTier 合约的单个等级
+type ContractTier struct {
+	Tier        int              `json:"tier"` // 从1开始
+	XP          int              `json:"xp"`   // 完成该等级需要的经验
+	Reward      ContractReward   `json:"reward"`
+	FreeRewards []ContractReward `json:"free_rewards,omitempty"` // 完成章节时获得的免费奖励
+	Unlocked    bool             `json:"unlocked"`
+}
+
+// ContractProgress 单个合约的进度
+type ContractProgress struct {
+	ContractID        string         `json:"contract_id"`
+	Name              string         `json:"name,omitempty"`
+	Icon              string         `json:"icon,omitempty"`
+	Type              string         `json:"type,omitempty"`        // Season、Agent或Event
+	RelationID        string         `json:"relation_id,omitempty"` // 小赛季或特工ID
+	Tier              int            `json:"tier"`                  // 已完成的等级数
+	TotalTiers        int            `json:"total_tiers"`
+	XPTowardsNextTier int            `json:"xp_towards_next_tier"`
+	XPForNextTier     int            `json:"xp_for_next_tier"` // 下一等级需要的总经验，已完成时为0
+	XPToNextTier      int            `json:"xp_to_next_tier"`
+	TotalXPEarned     int            `json:"total_xp_earned"`
+	Complete          bool           `json:"complete"`
+	Tiers             []ContractTier `json:"tiers,omitempty"` // 内容目录不可用时为空
+}
+
+// ContractsResponse 通行证、当前激活的合约和所有特工合约的进度
+type ContractsResponse struct {
+	BattlePass     *ContractProgress  `json:"battle_pass,omitempty"` // 当前小赛季的通行证
+	ActiveContract *ContractProgress  `json:"active_contract,omitempty"`
+	AgentContracts []ContractProgress `json:"agent_contracts"`
+}
+
+// 任务类型
+const (
+	MissionTypeDaily  = "EAresMissionType::Daily"
+	MissionTypeWeekly = "EAresMissionType::Weekly"
+)
+
+// ContentMission valorant-api.com中的任务
+type ContentMission struct {
+	UUID               string `json:"uuid"`
+	Title              string `json:"title"`
+	Type               string `json:"type"`
+	XPGrant            int    `json:"xpGrant"`
+	ProgressToComplete int    `json:"progressToComplete"`
+}
+
+// Mission 返回给客户端的任务
+type Mission struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title,omitempty"`
+	Progress  int       `json:"progress"`
+	Target    int       `json:"target"`
+	XP        int       `json:"xp"`
+	Complete  bool      `json:"complete"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// MissionsResponse 每日任务和每周任务
+type MissionsResponse struct {
+	Daily            []Mission `json:"daily"`
+	Weekly           []Mission `json:"weekly"`
+	WeeklyRefillTime time.Time `json:"weekly_refill_time"` // 下次刷新每周任务的时间
+}
+
+// MissionReminder 每周任务即将刷新但尚未完成时发送的提醒
+type MissionReminder struct {
+	UserID      string    `json:"user_id"`
+	Username    string    `json:"username"`
+	ResetsAt    time.Time `json:"resets_at"`
+	RemainingXP int       `json:"remaining_xp"` // 未完成任务的经验总和
+	Missions    []Mission `json:"missions"`     // 未完成的每周任务
+}
+
+// ValorantContentResponse Riot内容服务的响应，只包含赛季信息
+type ValorantContentResponse struct {
+	Seasons []struct {
+		ID        string    `json:"ID"`
+		Name      string    `json:"Name"`
+		Type      string    `json:"Type"` // episode或act
+		StartTime time.Time `json:"StartTime"`
+		EndTime   time.Time `json:"EndTime"`
+		IsActive  bool      `json:"IsActive"`
+	} `json:"Seasons"`
+}
+
+// ModeProjection 按某个模式完成通行证需要的比赛数
+type ModeProjection struct {
+	QueueID       string  `json:"queue_id"`
+	QueueName     string  `json:"queue_name,omitempty"`
+	AvgXPPerMatch int     `json:"avg_xp_per_match"`
+	Samples       int     `json:"samples"`         // 计算平均值使用的历史比赛数，为0时使用默认估计值
+	MatchesPerDay float64 `json:"matches_per_day"` // 每天需要的比赛数
+	MatchesTotal  int     `json:"matches_total"`   // 剩余时间内总共需要的比赛数
+}
+
+// ContractProjectionResponse 完成当前通行证的进度预测
+type ContractProjectionResponse struct {
+	ActID          string           `json:"act_id"`
+	ActName        string           `json:"act_name,omitempty"`
+	ActEndsAt      time.Time        `json:"act_ends_at"`
+	DaysRemaining  float64          `json:"days_remaining"`
+	Tier           int              `json:"tier"`
+	TotalTiers     int              `json:"total_tiers"`
+	RemainingXP    int              `json:"remaining_xp"`
+	XPPerDayNeeded int              `json:"xp_per_day_needed"`
+	XPPerDayEarned int              `json:"xp_per_day_earned"` // 本小赛季开始以来平均每天获得的经验
+	OnTrack        bool             `json:"on_track"`          // 按目前的速度能否在小赛季结束前完成
+	Complete       bool             `json:"complete"`
+	Modes          []ModeProjection `json:"modes"`
+}
@@ -0,0 +1,46 @@
+package models
+
+// 货币ID
+const (
+	CurrencyVP             = "85ad13f7-3d1b-5128-9eb2-7cd8ee0b5741" // Valorant Points
+	CurrencyRadianite      = "e59aa87c-4cbf-517a-5983-6e81511be9b7" // Radianite Points
+	CurrencyKingdomCredits = "85ca954a-41f2-ce94-9b45-8ca3dd39a00d" // Kingdom Credits
+)
+
+// Currency 游戏货币定义
+type Currency struct {
+	ID   string `json:"id"`
+	Code string `json:"code"` // 简写，例如VP
+	Name string `json:"name"`
+}
+
+// Currencies 已知的游戏货币，键为货币ID
+var Currencies = map[string]Currency{
+	CurrencyVP:             {ID: CurrencyVP, Code: "VP", Name: "Valorant Points"},
+	CurrencyRadianite:      {ID: CurrencyRadianite, Code: "RP", Name: "Radianite Points"},
+	CurrencyKingdomCredits: {ID: CurrencyKingdomCredits, Code: "KC", Name: "Kingdom Credits"},
+}
+
+// LookupCurrency 根据货币ID查找货币定义，未知货币只返回ID
+func LookupCurrency(id string) Currency {
+	if currency, ok := Currencies[id]; ok {
+		return currency
+	}
+	return Currency{ID: id}
+}
+
+// ValorantWalletResponse Riot钱包接口的响应
+type ValorantWalletResponse struct {
+	Balances map[string]int `json:"Balances"` // 货币ID -> 余额
+}
+
+// CurrencyBalance 单种货币的余额
+type CurrencyBalance struct {
+	Currency
+	Amount int `json:"amount"`
+}
+
+// WalletResponse 钱包响应
+type WalletResponse struct {
+	Balances []CurrencyBalance `json:"balances"`
+}
@@ -0,0 +1,36 @@
+package models
+
+// InventoryTypes 库存查询支持的类型及对应的物品类型ID
+var InventoryTypes = map[string]string{
+	"skins":   ItemTypeSkinLevel,
+	"chromas": ItemTypeSkinChroma,
+	"buddies": ItemTypeBuddy,
+	"sprays":  ItemTypeSpray,
+	"cards":   ItemTypePlayerCard,
+	"titles":  ItemTypeTitle,
+	"agents":  ItemTypeAgent,
+}
+
+// ValorantItemEntitlementsResponse Riot权益接口的响应
+type ValorantItemEntitlementsResponse struct {
+	ItemTypeID   string `json:"ItemTypeID"`
+	Entitlements []struct {
+		TypeID     string `json:"TypeID"`
+		ItemID     string `json:"ItemID"`
+		InstanceID string `json:"InstanceID,omitempty"`
+	} `json:"Entitlements"`
+}
+
+// InventoryItem 玩家拥有的物品
+type InventoryItem struct {
+	ItemInfo
+	ItemID      string   `json:"item_id"`
+	OwnedLevels []string `json:"owned_levels,omitempty"` // 皮肤已拥有的等级ID
+}
+
+// InventoryResponse 库存响应
+type InventoryResponse struct {
+	Type  string          `json:"type"`
+	Count int             `json:"count"`
+	Items []InventoryItem `json:"items"`
+}
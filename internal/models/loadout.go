@@ -0,0 +1,114 @@
+package models
+
+// ValorantLoadout Riot个性化服务中的玩家配置，读取和修改使用同一格式
+type ValorantLoadout struct {
+	Subject   string                 `json:"Subject"`
+	Version   int                    `json:"Version"`
+	Guns      []ValorantLoadoutGun   `json:"Guns"`
+	Sprays    []ValorantLoadoutSpray `json:"Sprays"`
+	Identity  ValorantIdentity       `json:"Identity"`
+	Incognito bool                   `json:"Incognito"`
+}
+
+// ValorantLoadoutGun 单把武器的外观
+type ValorantLoadoutGun struct {
+	ID              string   `json:"ID"` // 武器ID
+	SkinID          string   `json:"SkinID"`
+	SkinLevelID     string   `json:"SkinLevelID"`
+	ChromaID        string   `json:"ChromaID"`
+	CharmInstanceID string   `json:"CharmInstanceID,omitempty"` // 枪饰实例ID，来自权益中的InstanceID
+	CharmID         string   `json:"CharmID,omitempty"`
+	CharmLevelID    string   `json:"CharmLevelID,omitempty"`
+	Attachments     []string `json:"Attachments"`
+}
+
+// ValorantLoadoutSpray 喷漆槽位
+type ValorantLoadoutSpray struct {
+	EquipSlotID  string  `json:"EquipSlotID"`
+	SprayID      string  `json:"SprayID"`
+	SprayLevelID *string `json:"SprayLevelID"`
+}
+
+// ValorantIdentity 玩家卡面、称号等身份信息
+type ValorantIdentity struct {
+	PlayerCardID           string `json:"PlayerCardID"`
+	PlayerTitleID          string `json:"PlayerTitleID"`
+	AccountLevel           int    `json:"AccountLevel"`
+	PreferredLevelBorderID string `json:"PreferredLevelBorderID"`
+	HideAccountLevel       bool   `json:"HideAccountLevel"`
+}
+
+// LoadoutItem 配置中的单个物品
+type LoadoutItem struct {
+	ItemInfo
+	ID string `json:"id"`
+}
+
+// LoadoutGun 返回给客户端的单把武器外观
+type LoadoutGun struct {
+	Weapon    LoadoutItem  `json:"weapon"`
+	Skin      LoadoutItem  `json:"skin"`
+	SkinLevel LoadoutItem  `json:"skin_level"`
+	Chroma    LoadoutItem  `json:"chroma"`
+	Buddy     *LoadoutItem `json:"buddy,omitempty"` // 枪饰等级，未装备时为空
+}
+
+// LoadoutSpray 返回给客户端的喷漆槽位
+type LoadoutSpray struct {
+	LoadoutItem
+	SlotID string `json:"slot_id"`
+}
+
+// LoadoutResponse 玩家当前配置
+type LoadoutResponse struct {
+	Guns   []LoadoutGun   `json:"guns"`
+	Card   LoadoutItem    `json:"card"`
+	Title  LoadoutItem    `json:"title"`
+	Sprays []LoadoutSpray `json:"sprays"`
+}
+
+// GunChange 修改单把武器的外观
+// 只指定皮肤时使用已拥有的最高等级和默认炫彩；BuddyLevelID为nil表示不修改枪饰，为空字符串表示卸下枪饰
+type GunChange struct {
+	WeaponID     string  `json:"weapon_id" binding:"required"`
+	SkinID       string  `json:"skin_id,omitempty"`
+	SkinLevelID  string  `json:"skin_level_id,omitempty"`
+	ChromaID     string  `json:"chroma_id,omitempty"`
+	BuddyLevelID *string `json:"buddy_level_id,omitempty"`
+}
+
+// SprayChange 修改喷漆槽位
+type SprayChange struct {
+	SlotID  string `json:"slot_id" binding:"required"`
+	SprayID string `json:"spray_id" binding:"required"`
+}
+
+// LoadoutUpdateRequest 修改配置的请求，未指定的部分保持不变
+type LoadoutUpdateRequest struct {
+	Guns    []GunChange   `json:"guns,omitempty"`
+	CardID  string        `json:"card_id,omitempty"`
+	TitleID string        `json:"title_id,omitempty"`
+	Sprays  []SprayChange `json:"sprays,omitempty"`
+}
+
+// SkippedItem 因未拥有等原因无法装备的物品
+type SkippedItem struct {
+	Slot   string `json:"slot"` // 武器ID、card、title或喷漆槽位ID
+	ItemID string `json:"item_id"`
+	Reason string `json:"reason"`
+}
+
+// RandomizeLoadoutRequest 随机配置的请求
+type RandomizeLoadoutRequest struct {
+	WeaponIDs      []string `json:"weapon_ids,omitempty"`      // 只随机这些武器，为空表示所有武器
+	SameCollection bool     `json:"same_collection,omitempty"` // 所有武器使用同一系列的皮肤
+	MinTier        string   `json:"min_tier,omitempty"`        // 最低品质，可以是品质UUID或名称，例如Premium
+	Apply          bool     `json:"apply,omitempty"`           // 为false时只预览结果，不修改游戏配置
+}
+
+// RandomizeLoadoutResponse 随机配置的结果
+type RandomizeLoadoutResponse struct {
+	Applied      bool             `json:"applied"`
+	CollectionID string           `json:"collection_id,omitempty"` // 选中的皮肤系列，仅same_collection时返回
+	Loadout      *LoadoutResponse `json:"loadout"`
+}
@@ -0,0 +1,326 @@
+package models
+
+import "time"
+
+// ValorantMatchHistoryResponse Riot比赛历史接口的响应
+type ValorantMatchHistoryResponse struct {
+	Subject    string `json:"Subject"`
+	BeginIndex int    `json:"BeginIndex"`
+	EndIndex   int    `json:"EndIndex"`
+	Total      int    `json:"Total"`
+	History    []struct {
+		MatchID       string `json:"MatchID"`
+		GameStartTime int64  `json:"GameStartTime"` // 毫秒时间戳
+		QueueID       string `json:"QueueID"`
+	} `json:"History"`
+}
+
+// ValorantMatchDetails Riot比赛详情接口的响应，只包含用到的字段
+type ValorantMatchDetails struct {
+	MatchInfo    ValorantMatchInfo     `json:"matchInfo"`
+	Players      []ValorantMatchPlayer `json:"players"`
+	Teams        []ValorantMatchTeam   `json:"teams"`
+	RoundResults []ValorantRoundResult `json:"roundResults"`
+}
+
+// ValorantMatchInfo 比赛的基本信息
+type ValorantMatchInfo struct {
+	MatchID          string `json:"matchId"`
+	MapID            string `json:"mapId"` // 地图路径，例如/Game/Maps/Ascent/Ascent
+	GameLengthMillis int64  `json:"gameLengthMillis"`
+	GameStartMillis  int64  `json:"gameStartMillis"`
+	IsCompleted      bool   `json:"isCompleted"`
+	QueueID          string `json:"queueID"`
+	IsRanked         bool   `json:"isRanked"`
+	SeasonID         string `json:"seasonId"`
+}
+
+// ValorantMatchPlayer 比赛中的玩家
+type ValorantMatchPlayer struct {
+	Subject         string `json:"subject"`
+	GameName        string `json:"gameName"`
+	TagLine         string `json:"tagLine"`
+	TeamID          string `json:"teamId"`
+	PartyID         string `json:"partyId"`
+	CharacterID     string `json:"characterId"` // 特工ID
+	CompetitiveTier int    `json:"competitiveTier"`
+	Stats           struct {
+		Score        int `json:"score"`
+		RoundsPlayed int `json:"roundsPlayed"`
+		Kills        int `json:"kills"`
+		Deaths       int `json:"deaths"`
+		Assists      int `json:"assists"`
+	} `json:"stats"`
+}
+
+// ValorantMatchTeam 比赛中的队伍，死斗模式中每个玩家是一个队伍，teamId为玩家ID
+type ValorantMatchTeam struct {
+	TeamID       string `json:"teamId"`
+	Won          bool   `json:"won"`
+	RoundsPlayed int    `json:"roundsPlayed"`
+	RoundsWon    int    `json:"roundsWon"`
+	NumPoints    int    `json:"numPoints"`
+}
+
+// ValorantRoundResult 单个回合的结果
+type ValorantRoundResult struct {
+	RoundNum        int                        `json:"roundNum"` // 从0开始
+	RoundResult     string                     `json:"roundResult"`
+	RoundResultCode string                     `json:"roundResultCode"` // Elimination、Detonate、Defuse、Surrendered等
+	WinningTeam     string                     `json:"winningTeam"`
+	BombPlanter     string                     `json:"bombPlanter"`
+	PlantSite       string                     `json:"plantSite"`
+	PlayerStats     []ValorantRoundPlayerStats `json:"playerStats"`
+}
+
+// ValorantRoundPlayerStats 玩家在单个回合中的数据
+type ValorantRoundPlayerStats struct {
+	Subject string         `json:"subject"`
+	Kills   []ValorantKill `json:"kills"`
+	Damage  []struct {
+		Receiver  string `json:"receiver"`
+		Damage    int    `json:"damage"`
+		Legshots  int    `json:"legshots"`
+		Bodyshots int    `json:"bodyshots"`
+		Headshots int    `json:"headshots"`
+	} `json:"damage"`
+	Score   int `json:"score"`
+	Economy struct {
+		LoadoutValue int    `json:"loadoutValue"`
+		Weapon       string `json:"weapon"`
+		Armor        string `json:"armor"`
+		Remaining    int    `json:"remaining"`
+		Spent        int    `json:"spent"`
+	} `json:"economy"`
+}
+
+// ValorantLocation 比赛中的坐标
+type ValorantLocation struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// ValorantKill 单次击杀
+type ValorantKill struct {
+	GameTime        int64            `json:"gameTime"`
+	RoundTime       int64            `json:"roundTime"` // 回合开始后的毫秒数
+	Killer          string           `json:"killer"`
+	Victim          string           `json:"victim"`
+	VictimLocation  ValorantLocation `json:"victimLocation"`
+	Assistants      []string         `json:"assistants"`
+	PlayerLocations []struct {
+		Subject     string           `json:"subject"`
+		ViewRadians float64          `json:"viewRadians"`
+		Location    ValorantLocation `json:"location"`
+	} `json:"playerLocations"`
+	FinishingDamage struct {
+		DamageType          string `json:"damageType"` // Weapon、Ability、Bomb、Melee、Fall
+		DamageItem          string `json:"damageItem"` // 武器ID或技能槽位
+		IsSecondaryFireMode bool   `json:"isSecondaryFireMode"`
+	} `json:"finishingDamage"`
+}
+
+// 比赛结果
+const (
+	MatchResultVictory = "victory"
+	MatchResultDefeat  = "defeat"
+	MatchResultDraw    = "draw"
+)
+
+// MapInfo 返回给客户端的地图信息
+type MapInfo struct {
+	ID     string `json:"id"`
+	Name   string `json:"name,omitempty"`
+	Icon   string `json:"icon,omitempty"` // 列表图标
+	Splash string `json:"splash,omitempty"`
+}
+
+// MatchHistoryEntry 比赛历史中的单场比赛
+type MatchHistoryEntry struct {
+	MatchID    string    `json:"match_id"`
+	QueueID    string    `json:"queue_id"`
+	QueueName  string    `json:"queue_name,omitempty"`
+	Map        *MapInfo  `json:"map,omitempty"` // 比赛详情获取失败时为空
+	StartTime  time.Time `json:"start_time"`
+	Result     string    `json:"result,omitempty"` // victory、defeat或draw
+	RoundsWon  int       `json:"rounds_won"`
+	RoundsLost int       `json:"rounds_lost"`
+}
+
+// MatchHistoryResponse 分页的比赛历史
+type MatchHistoryResponse struct {
+	Total     int                 `json:"total"`
+	Start     int                 `json:"start"`
+	End       int                 `json:"end"`
+	NextStart *int                `json:"next_start,omitempty"` // 下一页的start参数，没有更多比赛时为空
+	Matches   []MatchHistoryEntry `json:"matches"`
+}
+
+// PlayerEconomy 玩家的经济数据
+type PlayerEconomy struct {
+	AvgLoadoutValue int `json:"avg_loadout_value"` // 每回合平均装备价值
+	AvgSpent        int `json:"avg_spent"`
+	TotalSpent      int `json:"total_spent"`
+}
+
+// PlayerMatchSummary 玩家在比赛中的数据
+type PlayerMatchSummary struct {
+	Subject         string        `json:"subject"`
+	Name            string        `json:"name"` // 游戏名#标签
+	TeamID          string        `json:"team_id"`
+	AgentID         string        `json:"agent_id"`
+	Agent           ItemInfo      `json:"agent"`
+	Kills           int           `json:"kills"`
+	Deaths          int           `json:"deaths"`
+	Assists         int           `json:"assists"`
+	ACS             int           `json:"acs"`              // 平均战斗得分
+	HeadshotPercent float64       `json:"headshot_percent"` // 爆头率，0到100
+	FirstBloods     int           `json:"first_bloods"`
+	Damage          int           `json:"damage"` // 造成的总伤害
+	ADR             int           `json:"adr"`    // 每回合平均伤害
+	Economy         PlayerEconomy `json:"economy"`
+}
+
+// TeamMatchSummary 队伍在比赛中的结果
+type TeamMatchSummary struct {
+	TeamID    string `json:"team_id"`
+	Won       bool   `json:"won"`
+	RoundsWon int    `json:"rounds_won"`
+}
+
+// RoundSummary 单个回合的结果
+type RoundSummary struct {
+	Round       int    `json:"round"` // 从1开始
+	WinningTeam string `json:"winning_team"`
+	Result      string `json:"result"` // Elimination、Detonate、Defuse、Surrendered等
+	PlantSite   string `json:"plant_site,omitempty"`
+}
+
+// MatchSummary 精简后的比赛详情
+type MatchSummary struct {
+	MatchID         string               `json:"match_id"`
+	Map             MapInfo              `json:"map"`
+	QueueID         string               `json:"queue_id"`
+	QueueName       string               `json:"queue_name,omitempty"`
+	SeasonID        string               `json:"season_id"`
+	StartTime       time.Time            `json:"start_time"`
+	DurationSeconds int64                `json:"duration_seconds"`
+	Completed       bool                 `json:"completed"`
+	Teams           []TeamMatchSummary   `json:"teams"`
+	Players         []PlayerMatchSummary `json:"players"` // 按队伍和ACS排序
+	Rounds          []RoundSummary       `json:"rounds"`
+}
+
+// ArchivedMatch 本地存档中的比赛索引，用于在不读取完整详情的情况下筛选比赛
+type ArchivedMatch struct {
+	MatchID      string    `json:"match_id"`
+	StartTime    time.Time `json:"start_time"`
+	QueueID      string    `json:"queue_id"`
+	MapID        string    `json:"map_id"`
+	SeasonID     string    `json:"season_id"`
+	AgentID      string    `json:"agent_id"`
+	Result       string    `json:"result"`
+	RoundsWon    int       `json:"rounds_won"`
+	RoundsLost   int       `json:"rounds_lost"`
+	RoundsPlayed int       `json:"rounds_played"`
+	Kills        int       `json:"kills"`
+	Deaths       int       `json:"deaths"`
+	Assists      int       `json:"assists"`
+	Score        int       `json:"score"`
+
+	// 以下数据在存档时从比赛详情中计算，用于统计接口
+	Headshots      int            `json:"headshots"`
+	Bodyshots      int            `json:"bodyshots"`
+	Legshots       int            `json:"legshots"`
+	ClutchAttempts int            `json:"clutch_attempts"`        // 成为队伍最后一人且仍有敌人存活的回合数
+	Clutches       int            `json:"clutches"`               // 其中最终获胜的回合数
+	WeaponKills    map[string]int `json:"weapon_kills,omitempty"` // 武器ID（小写）或伤害类型 -> 击杀数
+	StatsVersion   int            `json:"stats_version"`          // 计算方式变化时用于重新计算旧存档
+	// StatsUnavailable 重新计算时无法读取比赛详情，命中部位、残局和武器击杀数据缺失
+	StatsUnavailable bool `json:"stats_unavailable,omitempty"`
+}
+
+// ArchivedMatchEntry 返回给客户端的存档比赛
+type ArchivedMatchEntry struct {
+	MatchID    string    `json:"match_id"`
+	QueueID    string    `json:"queue_id"`
+	QueueName  string    `json:"queue_name,omitempty"`
+	Map        MapInfo   `json:"map"`
+	AgentID    string    `json:"agent_id"`
+	Agent      ItemInfo  `json:"agent"`
+	SeasonID   string    `json:"season_id"`
+	SeasonName string    `json:"season_name,omitempty"`
+	StartTime  time.Time `json:"start_time"`
+	Result     string    `json:"result"`
+	RoundsWon  int       `json:"rounds_won"`
+	RoundsLost int       `json:"rounds_lost"`
+	Kills      int       `json:"kills"`
+	Deaths     int       `json:"deaths"`
+	Assists    int       `json:"assists"`
+	ACS        int       `json:"acs"`
+}
+
+// MatchArchiveResponse 存档比赛的查询结果
+type MatchArchiveResponse struct {
+	Count   int                  `json:"count"`
+	Matches []ArchivedMatchEntry `json:"matches"` // 最近的比赛排在前面
+}
+
+// MatchSyncResponse 手动同步比赛存档的结果
+type MatchSyncResponse struct {
+	NewMatches int `json:"new_matches"`
+	Total      int `json:"total"` // 存档中的比赛总数
+}
+
+// MatchArchiveQuery 存档比赛的筛选条件，未指定的条件不限制
+type MatchArchiveQuery struct {
+	Map   string `form:"map"`   // 地图UUID、mapId或名称
+	Agent string `form:"agent"` // 特工UUID或名称
+	Act   string `form:"act"`   // 小赛季UUID，current表示当前小赛季
+	Queue string `form:"queue"` // 模式，例如competitive
+	Limit int    `form:"limit"` // 最多返回的比赛数，0表示不限制
+}
+
+// ClutchStats 残局数据
+type ClutchStats struct {
+	Attempts int     `json:"attempts"`
+	Won      int     `json:"won"`
+	WinRate  float64 `json:"win_rate"` // 0到100
+}
+
+// WeaponKillStat 单种武器的击杀数
+type WeaponKillStat struct {
+	ItemInfo
+	ID      string  `json:"id"` // 武器ID，非武器击杀为Ability、Bomb、Melee、Fall等伤害类型
+	Kills   int     `json:"kills"`
+	Percent float64 `json:"percent"` // 占总击杀的百分比
+}
+
+// StatsResponse 存档比赛的汇总统计
+type StatsResponse struct {
+	Matches         int              `json:"matches"`
+	Wins            int              `json:"wins"`
+	Losses          int              `json:"losses"`
+	Draws           int              `json:"draws"`
+	WinRate         float64          `json:"win_rate"` // 0到100
+	AvgACS          float64          `json:"avg_acs"`
+	Kills           int              `json:"kills"`
+	Deaths          int              `json:"deaths"`
+	Assists         int              `json:"assists"`
+	KD              float64          `json:"kd"`
+	HeadshotPercent float64          `json:"headshot_percent"`
+	Clutches        ClutchStats      `json:"clutches"`
+	WeaponKills     []WeaponKillStat `json:"weapon_kills"` // 按击杀数降序
+}
+
+// 热力图类型
+const (
+	HeatmapKills  = "kills"  // 玩家击杀时所在的位置
+	HeatmapDeaths = "deaths" // 玩家死亡的位置
+)
+
+// HeatmapQuery 热力图的请求参数，map必填
+type HeatmapQuery struct {
+	MatchArchiveQuery
+	Type string `form:"type"` // kills或deaths，默认为kills
+}
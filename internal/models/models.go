@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"github.com/golang-jwt/jwt/v5"
 )
 
@@ -20,16 +22,65 @@ type CookieLoginRequest struct {
 	Region  string `json:"region"` // 可选的区域设置参数
 }
 
+// PasswordLoginRequest 账号密码登录请求
+// 完成人机验证后重新提交时带上login_id和captcha_token
+type PasswordLoginRequest struct {
+	Username     string `json:"username" binding:"required"`
+	Password     string `json:"password" binding:"required"`
+	Region       string `json:"region"`
+	LoginID      string `json:"login_id,omitempty"`
+	CaptchaToken string `json:"captcha_token,omitempty"`
+}
+
+// MFALoginRequest 提交邮箱验证码的请求
+type MFALoginRequest struct {
+	LoginID        string `json:"login_id" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+	RememberDevice bool   `json:"remember_device,omitempty"`
+}
+
+// 登录挑战类型
+const (
+	LoginChallengeMFA     = "multifactor" // 需要邮箱验证码
+	LoginChallengeCaptcha = "captcha"     // 需要完成人机验证
+)
+
+// LoginChallenge 登录需要用户进一步操作时返回，后续请求使用login_id继续登录
+type LoginChallenge struct {
+	LoginID     string            `json:"login_id"`
+	Type        string            `json:"type"`
+	ExpiresAt   time.Time         `json:"expires_at"`
+	Multifactor *MFAChallenge     `json:"multifactor,omitempty"`
+	Captcha     *CaptchaChallenge `json:"captcha,omitempty"`
+}
+
+// MFAChallenge 二次验证信息
+type MFAChallenge struct {
+	Email  string `json:"email"` // Riot返回的打码邮箱
+	Method string `json:"method"`
+}
+
+// CaptchaChallenge 人机验证信息，客户端使用site_key和data渲染验证组件
+type CaptchaChallenge struct {
+	Provider string `json:"provider"` // 例如hcaptcha
+	SiteKey  string `json:"site_key"`
+	Data     string `json:"data,omitempty"`
+}
+
 // UserSession 用户会话信息
 type UserSession struct {
-	UserID       string            `json:"user_id"`
-	Username     string            `json:"username"`
-	AccessToken  string            `json:"access_token"`
-	Entitlement  string            `json:"entitlement_token"`
-	RiotUsername string            `json:"riot_username"`
-	RiotTagline  string            `json:"riot_tagline"`
-	Region       string            `json:"region"` // 用户区域
-	Cookies      map[string]string `json:"-"`      // Cookie不会返回给客户端
+	UserID         string            `json:"user_id"`
+	Username       string            `json:"username"`
+	AccessToken    string            `json:"access_token"`
+	Entitlement    string            `json:"entitlement_token"`
+	RiotUsername   string            `json:"riot_username"`
+	RiotTagline    string            `json:"riot_tagline"`
+	Region         string            `json:"region"`           // 用户区域
+	Shard          string            `json:"shard"`            // 区域对应的分片
+	Cookies        map[string]string `json:"-"`                // Cookie不会返回给客户端
+	ExpiresAt      time.Time         `json:"expires_at"`       // 会话过期时间，与JWT一致
+	TokenExpiresAt time.Time         `json:"token_expires_at"` // Riot访问令牌过期时间
+	Dead           bool              `json:"dead"`             // Cookie已失效，必须重新登录
 }
 
 // JWTClaims 定义JWT令牌的声明
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// UserSettings 按用户保存在服务器上的设置
+type UserSettings struct {
+	LoadoutPresets []LoadoutPreset `json:"loadout_presets"`
+}
+
+// LoadoutPreset 命名的配置预设
+type LoadoutPreset struct {
+	Name      string               `json:"name"`
+	Loadout   LoadoutUpdateRequest `json:"loadout"`
+	CreatedAt time.Time            `json:"created_at"`
+	UpdatedAt time.Time            `json:"updated_at"`
+}
+
+// SavePresetRequest 保存配置预设的请求，未提供配置时保存当前配置
+type SavePresetRequest struct {
+	Name    string                `json:"name" binding:"required"`
+	Loadout *LoadoutUpdateRequest `json:"loadout,omitempty"`
+}
+
+// ApplyPresetResponse 应用配置预设的结果
+type ApplyPresetResponse struct {
+	Loadout *LoadoutResponse `json:"loadout"`
+	Skipped []SkippedItem    `json:"skipped"` // 因不再拥有等原因跳过的物品
+}
@@ -0,0 +1,175 @@
+package models
+
+// StorefrontResponse Riot商店接口的响应
+type StorefrontResponse struct {
+	FeaturedBundle   FeaturedBundle   `json:"FeaturedBundle"`
+	SkinsPanelLayout SkinsPanelLayout `json:"SkinsPanelLayout"`
+	BonusStore       *BonusStore      `json:"BonusStore,omitempty"` // 夜市，未开放时为空
+	AccessoryStore   AccessoryStore   `json:"AccessoryStore"`
+}
+
+// FeaturedBundle 商店中的精选捆绑包
+type FeaturedBundle struct {
+	Bundle                           Bundle   `json:"Bundle"`
+	Bundles                          []Bundle `json:"Bundles"`
+	BundleRemainingDurationInSeconds int64    `json:"BundleRemainingDurationInSeconds"`
+}
+
+// Bundle 单个捆绑包
+type Bundle struct {
+	ID                         string         `json:"ID"`
+	DataAssetID                string         `json:"DataAssetID"` // 对应valorant-api.com中的捆绑包ID
+	CurrencyID                 string         `json:"CurrencyID"`
+	Items                      []BundleOffer  `json:"Items"`
+	TotalBaseCost              map[string]int `json:"TotalBaseCost"`
+	TotalDiscountedCost        map[string]int `json:"TotalDiscountedCost"`
+	TotalDiscountPercent       float64        `json:"TotalDiscountPercent"`
+	DurationRemainingInSeconds int64          `json:"DurationRemainingInSeconds"`
+	WholesaleOnly              bool           `json:"WholesaleOnly"` // 是否只能整包购买
+}
+
+// BundleOffer 捆绑包中的单个物品
+type BundleOffer struct {
+	Item struct {
+		ItemTypeID string `json:"ItemTypeID"`
+		ItemID     string `json:"ItemID"`
+		Amount     int    `json:"Amount"`
+	} `json:"Item"`
+	BasePrice       int     `json:"BasePrice"`
+	CurrencyID      string  `json:"CurrencyID"`
+	DiscountPercent float64 `json:"DiscountPercent"`
+	DiscountedPrice int     `json:"DiscountedPrice"`
+	IsPromoItem     bool    `json:"IsPromoItem"`
+}
+
+// SkinsPanelLayout 每日商店
+type SkinsPanelLayout struct {
+	SingleItemOffers                           []string     `json:"SingleItemOffers"`
+	SingleItemStoreOffers                      []StoreOffer `json:"SingleItemStoreOffers"`
+	SingleItemOffersRemainingDurationInSeconds int64        `json:"SingleItemOffersRemainingDurationInSeconds"`
+}
+
+// BonusStore 夜市
+type BonusStore struct {
+	BonusStoreOffers                     []BonusStoreOffer `json:"BonusStoreOffers"`
+	BonusStoreRemainingDurationInSeconds int64             `json:"BonusStoreRemainingDurationInSeconds"`
+}
+
+// BonusStoreOffer 夜市中的单个折扣商品
+type BonusStoreOffer struct {
+	BonusOfferID    string         `json:"BonusOfferID"`
+	Offer           StoreOffer     `json:"Offer"`
+	DiscountPercent int            `json:"DiscountPercent"`
+	DiscountCosts   map[string]int `json:"DiscountCosts"` // 货币ID -> 折后价格
+	IsSeen          bool           `json:"IsSeen"`        // 玩家是否已经翻开
+}
+
+// AccessoryStore 配件商店，出售枪饰、喷漆、卡面和称号，使用王国信用点购买
+type AccessoryStore struct {
+	AccessoryStoreOffers                     []AccessoryStoreOffer `json:"AccessoryStoreOffers"`
+	AccessoryStoreRemainingDurationInSeconds int64                 `json:"AccessoryStoreRemainingDurationInSeconds"`
+	StorefrontID                             string                `json:"StorefrontID"`
+}
+
+// AccessoryStoreOffer 配件商店中的单个商品
+type AccessoryStoreOffer struct {
+	Offer      StoreOffer `json:"Offer"`
+	ContractID string     `json:"ContractID"`
+}
+
+// StoreOffer 商店中的单个商品报价
+type StoreOffer struct {
+	OfferID          string         `json:"OfferID"`
+	IsDirectPurchase bool           `json:"IsDirectPurchase"`
+	StartDate        string         `json:"StartDate"`
+	Cost             map[string]int `json:"Cost"` // 货币ID -> 价格
+	Rewards          []OfferReward  `json:"Rewards"`
+}
+
+// OfferReward 购买报价后获得的物品
+type OfferReward struct {
+	ItemTypeID string `json:"ItemTypeID"`
+	ItemID     string `json:"ItemID"`
+	Quantity   int    `json:"Quantity"`
+}
+
+// StoreItem 返回给客户端的商店物品
+type StoreItem struct {
+	ItemInfo
+	OfferID    string `json:"offer_id"`
+	ItemID     string `json:"item_id"`
+	ItemTypeID string `json:"item_type_id"`
+	Cost       int    `json:"cost"` // VP价格
+}
+
+// DailyStoreResponse 每日商店响应
+type DailyStoreResponse struct {
+	Offers           []StoreItem `json:"offers"`
+	RemainingSeconds int64       `json:"remaining_seconds"` // 距离下次刷新的秒数
+}
+
+// NightMarketItem 夜市中的折扣物品
+type NightMarketItem struct {
+	StoreItem              // Cost为原价
+	DiscountedCost   int   `json:"discounted_cost"`   // 折后VP价格
+	DiscountPercent  int   `json:"discount_percent"`  // 折扣百分比
+	Revealed         bool  `json:"revealed"`          // 是否已经翻开
+	RemainingSeconds int64 `json:"remaining_seconds"` // 距离夜市结束的秒数
+}
+
+// NightMarketResponse 夜市响应，夜市未开放时Active为false
+type NightMarketResponse struct {
+	Active           bool              `json:"active"`
+	Offers           []NightMarketItem `json:"offers"`
+	RemainingSeconds int64             `json:"remaining_seconds"`
+}
+
+// BundleItem 捆绑包中的物品及其价格
+type BundleItem struct {
+	ItemInfo
+	ItemID          string `json:"item_id"`
+	ItemTypeID      string `json:"item_type_id"`
+	Amount          int    `json:"amount"`
+	BasePrice       int    `json:"base_price"`       // 单独购买的价格
+	DiscountedPrice int    `json:"discounted_price"` // 在捆绑包中的价格
+	IsPromoItem     bool   `json:"is_promo_item"`    // 是否为捆绑包赠品
+}
+
+// BundleInfo 捆绑包及其价格明细
+type BundleInfo struct {
+	ItemInfo
+	BundleID         string       `json:"bundle_id"`
+	CurrencyID       string       `json:"currency_id"` // 保留给旧版客户端，与Currency.ID相同
+	Currency         Currency     `json:"currency"`
+	Items            []BundleItem `json:"items"`
+	BasePrice        int          `json:"base_price"`        // 所有物品单独购买的总价
+	DiscountedPrice  int          `json:"discounted_price"`  // 整包购买的价格
+	Savings          int          `json:"savings"`           // 整包购买节省的金额
+	DiscountPercent  float64      `json:"discount_percent"`  // 整包折扣百分比
+	WholesaleOnly    bool         `json:"wholesale_only"`    // 是否只能整包购买
+	RemainingSeconds int64        `json:"remaining_seconds"` // 距离下架的秒数
+}
+
+// BundlesResponse 精选捆绑包响应
+type BundlesResponse struct {
+	Bundles []BundleInfo `json:"bundles"`
+}
+
+// AccessoryItem 配件商店中的物品
+type AccessoryItem struct {
+	ItemInfo
+	OfferID    string `json:"offer_id"`
+	ItemID     string `json:"item_id"`
+	ItemTypeID string `json:"item_type_id"`
+	Type       string `json:"type"` // buddies、sprays、cards或titles，与库存接口的type相同
+	Quantity   int    `json:"quantity"`
+	Cost       int    `json:"cost"`  // 王国信用点价格
+	Owned      bool   `json:"owned"` // 是否已经拥有
+}
+
+// AccessoryStoreResponse 配件商店响应
+type AccessoryStoreResponse struct {
+	Currency         Currency        `json:"currency"`
+	Offers           []AccessoryItem `json:"offers"`
+	RemainingSeconds int64           `json:"remaining_seconds"` // 距离下次刷新的秒数
+}
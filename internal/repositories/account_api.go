@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/emper0r/val-store-server/internal/models"
+)
+
+// GetAccountXP 获取玩家的账号等级、经验和最近的经验来源
+func (rc *RiotClient) GetAccountXP() (*models.ValorantAccountXPResponse, error) {
+	var accountXP models.ValorantAccountXPResponse
+	if err := rc.doJSON(http.MethodGet, rc.pdURL("/account-xp/v1/players/"+rc.puuid), nil, &accountXP); err != nil {
+		return nil, fmt.Errorf("获取账号经验失败: %w", err)
+	}
+
+	return &accountXP, nil
+}
+
+// GetMMR 获取玩家的段位、排名分和各赛季的竞技模式战绩
+func (rc *RiotClient) GetMMR() (*models.ValorantMMRResponse, error) {
+	var mmr models.ValorantMMRResponse
+	if err := rc.doJSON(http.MethodGet, rc.pdURL("/mmr/v1/players/"+rc.puuid), nil, &mmr); err != nil {
+		return nil, fmt.Errorf("获取段位信息失败: %w", err)
+	}
+
+	return &mmr, nil
+}
@@ -0,0 +1,789 @@
+package repositories
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/emper0r/val-store-server/internal/models"
+)
+
+const (
+	// contentBaseURL valorant-api.com的API地址
+	contentBaseURL = "https://valorant-api.com/v1"
+
+	// DefaultContentLanguage 未指定语言时使用的内容语言
+	DefaultContentLanguage = "en-US"
+
+	// catalogRetryInterval 加载失败后多久才允许重新下载，期间直接返回上次的错误
+	catalogRetryInterval = time.Minute
+)
+
+// supportedLanguages valorant-api.com支持的语言
+var supportedLanguages = []string{
+	"ar-AE", "de-DE", "en-US", "es-ES", "es-MX", "fr-FR", "id-ID", "it-IT", "ja-JP",
+	"ko-KR", "pl-PL", "pt-BR", "ru-RU", "th-TH", "tr-TR", "vi-VN", "zh-CN", "zh-TW",
+}
+
+// contentEnvelope valorant-api.com的通用响应格式
+type contentEnvelope struct {
+	Status int             `json:"status"`
+	Data   json.RawMessage `json:"data"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// ContentCatalog 从valorant-api.com下载游戏内容，并按客户端版本和语言缓存到磁盘
+type ContentCatalog struct {
+	client          *http.Client
+	baseURL         string
+	cacheDir        string
+	version         string
+	defaultLanguage string
+
+	mu       sync.Mutex
+	catalogs map[string]*catalogEntry // 语言 -> 内容目录
+}
+
+// catalogEntry 单个语言的内容目录，各语言独立加载互不阻塞
+// mu只保护字段，下载在锁外进行；同时到达的请求等待loading而不是重复下载
+type catalogEntry struct {
+	mu       sync.Mutex
+	catalog  *Catalog
+	loading  chan struct{} // 正在加载时不为空，加载结束后关闭
+	err      error         // 最近一次加载失败的错误
+	failedAt time.Time
+	loadedAt time.Time
+}
+
+// NewContentCatalog 创建内容目录，复用ValorantAPI的Transport和客户端版本
+func NewContentCatalog(valorantAPI *ValorantAPI, cacheDir, defaultLanguage string) *ContentCatalog {
+	c := &ContentCatalog{
+		client: &http.Client{
+			Timeout:   60 * time.Second,
+			Transport: valorantAPI.transport,
+		},
+		baseURL:         contentBaseURL,
+		cacheDir:        cacheDir,
+		version:         valorantAPI.clientVersion,
+		defaultLanguage: DefaultContentLanguage,
+		catalogs:        make(map[string]*catalogEntry),
+	}
+	c.defaultLanguage = c.NormalizeLanguage(defaultLanguage)
+
+	return c
+}
+
+// NormalizeLanguage 将请求中的语言转换为valorant-api.com支持的语言
+// 支持完整的语言代码（忽略大小写）和只有语种的简写，例如zh、ja
+func (c *ContentCatalog) NormalizeLanguage(language string) string {
+	language = strings.ReplaceAll(strings.TrimSpace(language), "_", "-")
+	if language == "" {
+		return c.defaultLanguage
+	}
+
+	for _, supported := range supportedLanguages {
+		if strings.EqualFold(language, supported) {
+			return supported
+		}
+	}
+
+	// 只有语种时取该语种的第一个地区，例如zh -> zh-CN
+	prefix := strings.ToLower(strings.SplitN(language, "-", 2)[0])
+	for _, supported := range supportedLanguages {
+		if strings.HasPrefix(strings.ToLower(supported), prefix+"-") {
+			return supported
+		}
+	}
+
+	return c.defaultLanguage
+}
+
+// Get 返回指定语言的内容目录，首次调用时从磁盘缓存或网络加载
+func (c *ContentCatalog) Get(language string) (*Catalog, error) {
+	language = c.NormalizeLanguage(language)
+
+	c.mu.Lock()
+	entry, ok := c.catalogs[language]
+	if !ok {
+		entry = &catalogEntry{}
+		c.catalogs[language] = entry
+	}
+	c.mu.Unlock()
+
+	for {
+		entry.mu.Lock()
+		if entry.catalog != nil {
+			catalog := entry.catalog
+			// 部分内容加载失败时在后台重新加载，期间继续使用当前目录
+			if len(catalog.missing) > 0 && entry.loading == nil && time.Since(entry.loadedAt) >= catalogRetryInterval {
+				entry.loading = make(chan struct{})
+				go c.reload(entry, language)
+			}
+			entry.mu.Unlock()
+			return catalog, nil
+		}
+
+		// 其他请求正在加载时等待其结果
+		if loading := entry.loading; loading != nil {
+			entry.mu.Unlock()
+			<-loading
+			continue
+		}
+
+		// 最近失败过时直接返回错误，避免valorant-api.com不可用时每个请求都重新下载
+		if entry.err != nil && time.Since(entry.failedAt) < catalogRetryInterval {
+			err := entry.err
+			entry.mu.Unlock()
+			return nil, err
+		}
+
+		loading := make(chan struct{})
+		entry.loading = loading
+		entry.mu.Unlock()
+
+		catalog, err := c.load(language)
+
+		entry.mu.Lock()
+		if err != nil {
+			entry.err = err
+			entry.failedAt = time.Now()
+		} else {
+			entry.catalog = catalog
+			entry.loadedAt = time.Now()
+			entry.err = nil
+		}
+		entry.loading = nil
+		close(loading)
+		entry.mu.Unlock()
+
+		if err != nil {
+			return nil, err
+		}
+		return catalog, nil
+	}
+}
+
+// reload 在后台重新加载不完整的内容目录，调用前entry.loading已被设置
+func (c *ContentCatalog) reload(entry *catalogEntry, language string) {
+	catalog, err := c.load(language)
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if err != nil {
+		log.Printf("警告: 重新加载游戏内容失败: %v", err)
+	} else if len(catalog.missing) < len(entry.catalog.missing) {
+		entry.catalog = catalog
+	}
+	entry.loadedAt = time.Now()
+	close(entry.loading)
+	entry.loading = nil
+}
+
+// Preload 预先加载默认语言的内容目录，失败时只记录日志
+func (c *ContentCatalog) Preload() {
+	start := time.Now()
+	if _, err := c.Get(c.defaultLanguage); err != nil {
+		log.Printf("警告: 预加载游戏内容失败: %v", err)
+		return
+	}
+	log.Printf("游戏内容加载完成，版本: %s，语言: %s，耗时: %v", c.version, c.defaultLanguage, time.Since(start))
+}
+
+// load 加载指定语言的所有类型的游戏内容并建立索引
+func (c *ContentCatalog) load(language string) (*Catalog, error) {
+	catalog := newCatalog(c.version, language)
+
+	// 武器接口中已经包含皮肤及其等级和炫彩，从中建立索引可以同时记录所属武器和皮肤
+	var weapons []models.ContentWeapon
+	if err := c.fetch(language, "weapons", "/weapons", &weapons); err != nil {
+		return nil, err
+	}
+	catalog.indexWeapons(weapons)
+
+	var tiers []models.ContentTier
+	if err := c.fetch(language, "contenttiers", "/contenttiers", &tiers); err != nil {
+		return nil, err
+	}
+	for i := range tiers {
+		catalog.ContentTiers[strings.ToLower(tiers[i].UUID)] = &tiers[i]
+	}
+
+	var bundles []models.ContentBundle
+	if err := c.fetch(language, "bundles", "/bundles", &bundles); err != nil {
+		return nil, err
+	}
+	for i := range bundles {
+		catalog.Bundles[strings.ToLower(bundles[i].UUID)] = &bundles[i]
+	}
+
+	var buddies []models.ContentBuddy
+	if err := c.fetch(language, "buddies", "/buddies", &buddies); err != nil {
+		return nil, err
+	}
+	catalog.indexBuddies(buddies)
+
+	var sprays []models.ContentSpray
+	if err := c.fetch(language, "sprays", "/sprays", &sprays); err != nil {
+		return nil, err
+	}
+	for i := range sprays {
+		catalog.Sprays[strings.ToLower(sprays[i].UUID)] = &sprays[i]
+	}
+
+	var cards []models.ContentPlayerCard
+	if err := c.fetch(language, "playercards", "/playercards", &cards); err != nil {
+		return nil, err
+	}
+	for i := range cards {
+		catalog.PlayerCards[strings.ToLower(cards[i].UUID)] = &cards[i]
+	}
+
+	var titles []models.ContentPlayerTitle
+	if err := c.fetch(language, "playertitles", "/playertitles", &titles); err != nil {
+		return nil, err
+	}
+	for i := range titles {
+		catalog.PlayerTitles[strings.ToLower(titles[i].UUID)] = &titles[i]
+	}
+
+	var agents []models.ContentAgent
+	if err := c.fetch(language, "agents", "/agents", &agents); err != nil {
+		return nil, err
+	}
+	for i := range agents {
+		catalog.Agents[strings.ToLower(agents[i].UUID)] = &agents[i]
+	}
+
+	// 以下内容只用于各自的功能，加载失败时只记录日志并保持为空，不影响商店和库存等核心功能
+	var borders []models.ContentLevelBorder
+	c.fetchOptional(catalog, "levelborders", "/levelborders", &borders)
+	for i := range borders {
+		catalog.LevelBorders = append(catalog.LevelBorders, &borders[i])
+	}
+	sort.Slice(catalog.LevelBorders, func(i, j int) bool {
+		return catalog.LevelBorders[i].StartingLevel < catalog.LevelBorders[j].StartingLevel
+	})
+
+	var seasons []models.ContentSeason
+	c.fetchOptional(catalog, "seasons", "/seasons", &seasons)
+	for i := range seasons {
+		catalog.Seasons[strings.ToLower(seasons[i].UUID)] = &seasons[i]
+	}
+
+	var competitiveSeasons []models.ContentCompetitiveSeason
+	c.fetchOptional(catalog, "competitiveseasons", "/seasons/competitive", &competitiveSeasons)
+	for _, season := range competitiveSeasons {
+		catalog.SeasonTiers[strings.ToLower(season.SeasonUUID)] = strings.ToLower(season.CompetitiveTiersUUID)
+	}
+
+	var tierSets []models.ContentCompetitiveTierSet
+	c.fetchOptional(catalog, "competitivetiers", "/competitivetiers", &tierSets)
+	for i := range tierSets {
+		catalog.CompetitiveTiers[strings.ToLower(tierSets[i].UUID)] = &tierSets[i]
+	}
+	if len(tierSets) > 0 {
+		catalog.latestTierSet = &tierSets[len(tierSets)-1]
+	}
+
+	var maps []models.ContentMap
+	c.fetchOptional(catalog, "maps", "/maps", &maps)
+	for i := range maps {
+		catalog.Maps[strings.ToLower(maps[i].UUID)] = &maps[i]
+		if maps[i].MapURL != "" {
+			catalog.Maps[strings.ToLower(maps[i].MapURL)] = &maps[i]
+		}
+	}
+
+	var queues []models.ContentQueue
+	c.fetchOptional(catalog, "queues", "/gamemodes/queues", &queues)
+	for i := range queues {
+		catalog.Queues[strings.ToLower(queues[i].QueueID)] = &queues[i]
+	}
+
+	var contracts []models.ContentContract
+	c.fetchOptional(catalog, "contracts", "/contracts", &contracts)
+	for i := range contracts {
+		catalog.Contracts[strings.ToLower(contracts[i].UUID)] = &contracts[i]
+	}
+
+	var missions []models.ContentMission
+	c.fetchOptional(catalog, "missions", "/missions", &missions)
+	for i := range missions {
+		catalog.Missions[strings.ToLower(missions[i].UUID)] = &missions[i]
+	}
+
+	return catalog, nil
+}
+
+// fetchOptional 读取非核心的内容，失败时记录日志并标记目录不完整，稍后重新加载
+func (c *ContentCatalog) fetchOptional(catalog *Catalog, kind, path string, out interface{}) {
+	if err := c.fetch(catalog.Language, kind, path, out); err != nil {
+		log.Printf("警告: 加载游戏内容%s失败，相关功能暂不可用: %v", kind, err)
+		catalog.missing = append(catalog.missing, kind)
+	}
+}
+
+// cachePath 返回内容缓存文件的路径，路径中包含客户端版本和语言
+func (c *ContentCatalog) cachePath(language, kind string) string {
+	return filepath.Join(c.cacheDir, c.version, language, kind+".json")
+}
+
+// fetch 读取指定语言和类型的内容，优先使用磁盘缓存
+func (c *ContentCatalog) fetch(language, kind, path string, out interface{}) error {
+	cachePath := c.cachePath(language, kind)
+
+	data, err := os.ReadFile(cachePath)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Printf("警告: 读取内容缓存 %s 失败: %v", cachePath, err)
+		}
+
+		data, err = c.download(path, language)
+		if err != nil {
+			return fmt.Errorf("下载%s失败: %w", kind, err)
+		}
+
+		if err := writeFileAtomic(cachePath, data); err != nil {
+			log.Printf("警告: 写入内容缓存 %s 失败: %v", cachePath, err)
+		}
+	}
+
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("解析%s失败: %w", kind, err)
+	}
+
+	return nil
+}
+
+// download 从valorant-api.com下载指定语言的内容，返回data字段
+func (c *ContentCatalog) download(path, language string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, c.baseURL+path+"?language="+url.QueryEscape(language), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "val-store-server")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("状态码: %d, 响应: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var envelope contentEnvelope
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return nil, err
+	}
+	if envelope.Status != http.StatusOK || len(envelope.Data) == 0 {
+		return nil, fmt.Errorf("无效的响应，状态: %d, 错误: %s", envelope.Status, envelope.Error)
+	}
+
+	return envelope.Data, nil
+}
+
+// Catalog 内存中的游戏内容目录，所有索引的键都是小写的UUID
+type Catalog struct {
+	Version      string
+	Language     string
+	Weapons      map[string]*models.ContentWeapon
+	Skins        map[string]*models.ContentSkin
+	SkinLevels   map[string]*models.ContentSkinLevel
+	Chromas      map[string]*models.ContentChroma
+	ContentTiers map[string]*models.ContentTier
+	Bundles      map[string]*models.ContentBundle
+	Buddies      map[string]*models.ContentBuddy
+	BuddyLevels  map[string]*models.ContentBuddyLevel
+	Sprays       map[string]*models.ContentSpray
+	PlayerCards  map[string]*models.ContentPlayerCard
+	PlayerTitles map[string]*models.ContentPlayerTitle
+	Agents       map[string]*models.ContentAgent
+	LevelBorders []*models.ContentLevelBorder // 按startingLevel升序排列
+	Seasons      map[string]*models.ContentSeason
+	// SeasonTiers 赛季ID -> 段位组ID
+	SeasonTiers      map[string]string
+	CompetitiveTiers map[string]*models.ContentCompetitiveTierSet
+	// Maps 地图，同时按UUID和mapUrl建立索引
+	Maps map[string]*models.ContentMap
+	// Queues 匹配队列，按queueId建立索引
+	Queues    map[string]*models.ContentQueue
+	Contracts map[string]*models.ContentContract
+	Missions  map[string]*models.ContentMission
+	// latestTierSet 最新的段位组，赛季没有对应的段位组时使用
+	latestTierSet *models.ContentCompetitiveTierSet
+	// missing 加载失败的非核心内容类型
+	missing []string
+}
+
+// newCatalog 创建空的内容目录
+func newCatalog(version, language string) *Catalog {
+	return &Catalog{
+		Version:          version,
+		Language:         language,
+		Weapons:          make(map[string]*models.ContentWeapon),
+		Skins:            make(map[string]*models.ContentSkin),
+		SkinLevels:       make(map[string]*models.ContentSkinLevel),
+		Chromas:          make(map[string]*models.ContentChroma),
+		ContentTiers:     make(map[string]*models.ContentTier),
+		Bundles:          make(map[string]*models.ContentBundle),
+		Buddies:          make(map[string]*models.ContentBuddy),
+		BuddyLevels:      make(map[string]*models.ContentBuddyLevel),
+		Sprays:           make(map[string]*models.ContentSpray),
+		PlayerCards:      make(map[string]*models.ContentPlayerCard),
+		PlayerTitles:     make(map[string]*models.ContentPlayerTitle),
+		Agents:           make(map[string]*models.ContentAgent),
+		Seasons:          make(map[string]*models.ContentSeason),
+		SeasonTiers:      make(map[string]string),
+		CompetitiveTiers: make(map[string]*models.ContentCompetitiveTierSet),
+		Maps:             make(map[string]*models.ContentMap),
+		Queues:           make(map[string]*models.ContentQueue),
+		Contracts:        make(map[string]*models.ContentContract),
+		Missions:         make(map[string]*models.ContentMission),
+	}
+}
+
+// indexWeapons 为武器及其皮肤、等级、炫彩建立索引
+func (c *Catalog) indexWeapons(weapons []models.ContentWeapon) {
+	for i := range weapons {
+		weapon := &weapons[i]
+		c.Weapons[strings.ToLower(weapon.UUID)] = weapon
+
+		for j := range weapon.Skins {
+			weapon.Skins[j].WeaponUUID = weapon.UUID
+		}
+		c.indexSkins(weapon.Skins)
+	}
+}
+
+// indexSkins 为皮肤及其等级、炫彩建立索引
+func (c *Catalog) indexSkins(skins []models.ContentSkin) {
+	for i := range skins {
+		skin := &skins[i]
+		c.Skins[strings.ToLower(skin.UUID)] = skin
+
+		for j := range skin.Levels {
+			skin.Levels[j].SkinUUID = skin.UUID
+			c.SkinLevels[strings.ToLower(skin.Levels[j].UUID)] = &skin.Levels[j]
+		}
+		for j := range skin.Chromas {
+			skin.Chromas[j].SkinUUID = skin.UUID
+			c.Chromas[strings.ToLower(skin.Chromas[j].UUID)] = &skin.Chromas[j]
+		}
+	}
+}
+
+// indexBuddies 为枪饰及其等级建立索引
+func (c *Catalog) indexBuddies(buddies []models.ContentBuddy) {
+	for i := range buddies {
+		buddy := &buddies[i]
+		c.Buddies[strings.ToLower(buddy.UUID)] = buddy
+
+		for j := range buddy.Levels {
+			buddy.Levels[j].BuddyUUID = buddy.UUID
+			c.BuddyLevels[strings.ToLower(buddy.Levels[j].UUID)] = &buddy.Levels[j]
+		}
+	}
+}
+
+// ParentSkin 返回皮肤等级或炫彩所属的皮肤
+func (c *Catalog) ParentSkin(uuid string) (*models.ContentSkin, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	key := strings.ToLower(uuid)
+	if level, ok := c.SkinLevels[key]; ok {
+		skin, ok := c.Skins[strings.ToLower(level.SkinUUID)]
+		return skin, ok
+	}
+	if chroma, ok := c.Chromas[key]; ok {
+		skin, ok := c.Skins[strings.ToLower(chroma.SkinUUID)]
+		return skin, ok
+	}
+
+	return nil, false
+}
+
+// LevelBorder 返回账号等级对应的等级边框，目录为空或没有匹配的边框时返回nil
+func (c *Catalog) LevelBorder(level int) *models.ContentLevelBorder {
+	if c == nil {
+		return nil
+	}
+
+	var border *models.ContentLevelBorder
+	for _, candidate := range c.LevelBorders {
+		if candidate.StartingLevel > level {
+			break
+		}
+		border = candidate
+	}
+	return border
+}
+
+// CurrentAct 返回指定时间所在的小赛季，目录为空或不在任何小赛季中时返回nil
+func (c *Catalog) CurrentAct(now time.Time) *models.ContentSeason {
+	if c == nil {
+		return nil
+	}
+
+	for _, season := range c.Seasons {
+		if season.Type == models.SeasonTypeAct && !now.Before(season.StartTime) && now.Before(season.EndTime) {
+			return season
+		}
+	}
+	return nil
+}
+
+// SeasonName 返回赛季的完整名称，小赛季会带上所属大赛季，例如EPISODE 8 ACT I
+func (c *Catalog) SeasonName(seasonID string) string {
+	if c == nil {
+		return ""
+	}
+
+	season, ok := c.Seasons[strings.ToLower(seasonID)]
+	if !ok {
+		return ""
+	}
+	if parent, ok := c.Seasons[strings.ToLower(season.ParentUUID)]; ok {
+		return parent.DisplayName + " " + season.DisplayName
+	}
+	return season.DisplayName
+}
+
+// SeasonStart 返回赛季的开始时间，目录为空或赛季未知时返回零值
+func (c *Catalog) SeasonStart(seasonID string) time.Time {
+	if c == nil {
+		return time.Time{}
+	}
+
+	if season, ok := c.Seasons[strings.ToLower(seasonID)]; ok {
+		return season.StartTime
+	}
+	return time.Time{}
+}
+
+// ResolveRank 解析赛季中的段位，赛季未知时使用最新的段位组
+func (c *Catalog) ResolveRank(seasonID string, tier int) models.RankInfo {
+	rank := models.RankInfo{Tier: tier}
+	if c == nil {
+		return rank
+	}
+
+	tierSet := c.latestTierSet
+	if id, ok := c.SeasonTiers[strings.ToLower(seasonID)]; ok {
+		if set, ok := c.CompetitiveTiers[id]; ok {
+			tierSet = set
+		}
+	}
+	if tierSet == nil {
+		return rank
+	}
+
+	for _, candidate := range tierSet.Tiers {
+		if candidate.Tier != tier {
+			continue
+		}
+		color := candidate.Color
+		if len(color) == 8 {
+			color = color[:6]
+		}
+		rank.Name = candidate.TierName
+		rank.Division = candidate.DivisionName
+		if color != "" {
+			rank.Color = "#" + color
+		}
+		rank.Icon = candidate.SmallIcon
+		rank.LargeIcon = candidate.LargeIcon
+		break
+	}
+	return rank
+}
+
+// ResolveMap 根据地图UUID或比赛详情中的mapId解析地图
+func (c *Catalog) ResolveMap(mapID string) models.MapInfo {
+	info := models.MapInfo{ID: mapID}
+	if c == nil {
+		return info
+	}
+
+	if m, ok := c.Maps[strings.ToLower(mapID)]; ok {
+		info.Name = m.DisplayName
+		info.Icon = m.ListViewIcon
+		info.Splash = m.Splash
+	}
+	return info
+}
+
+// FindMap 按UUID、mapId或名称（不区分大小写）查找地图
+func (c *Catalog) FindMap(value string) *models.ContentMap {
+	if c == nil {
+		return nil
+	}
+
+	if m, ok := c.Maps[strings.ToLower(value)]; ok {
+		return m
+	}
+	for _, m := range c.Maps {
+		if strings.EqualFold(m.DisplayName, value) {
+			return m
+		}
+	}
+	return nil
+}
+
+// FindAgent 按UUID或名称（不区分大小写）查找特工
+func (c *Catalog) FindAgent(value string) *models.ContentAgent {
+	if c == nil {
+		return nil
+	}
+
+	if agent, ok := c.Agents[strings.ToLower(value)]; ok {
+		return agent
+	}
+	for _, agent := range c.Agents {
+		if strings.EqualFold(agent.DisplayName, value) {
+			return agent
+		}
+	}
+	return nil
+}
+
+// QueueName 返回匹配队列的名称，未知时返回空字符串
+func (c *Catalog) QueueName(queueID string) string {
+	if c == nil {
+		return ""
+	}
+
+	if queue, ok := c.Queues[strings.ToLower(queueID)]; ok {
+		return queue.DisplayName
+	}
+	return ""
+}
+
+// ResolveTier 解析皮肤品质，目录为空或品质不存在时返回nil
+func (c *Catalog) ResolveTier(uuid string) *models.TierInfo {
+	if c == nil {
+		return nil
+	}
+
+	tier, ok := c.ContentTiers[strings.ToLower(uuid)]
+	if !ok {
+		return nil
+	}
+
+	// highlightColor为RRGGBBAA格式，去掉透明度
+	color := tier.HighlightColor
+	if len(color) == 8 {
+		color = color[:6]
+	}
+
+	// displayName随语言本地化，缺失时退回内部名称
+	name := tier.DisplayName
+	if name == "" {
+		name = tier.DevName
+	}
+
+	return &models.TierInfo{
+		UUID:  tier.UUID,
+		Name:  name,
+		Color: "#" + color,
+		Icon:  tier.DisplayIcon,
+	}
+}
+
+// ResolveItem 根据UUID解析物品的名称、图片和品质
+// 目录为空或找不到物品时返回false，调用方可以只返回原始ID
+func (c *Catalog) ResolveItem(uuid string) (models.ItemInfo, bool) {
+	if c == nil {
+		return models.ItemInfo{}, false
+	}
+
+	key := strings.ToLower(uuid)
+
+	if level, ok := c.SkinLevels[key]; ok {
+		skin := c.Skins[strings.ToLower(level.SkinUUID)]
+		icon := level.DisplayIcon
+		if icon == "" {
+			icon = skin.DisplayIcon
+		}
+		return models.ItemInfo{
+			Name: skin.DisplayName,
+			Icon: icon,
+			Tier: c.ResolveTier(skin.ContentTierUUID),
+		}, true
+	}
+
+	if skin, ok := c.Skins[key]; ok {
+		return models.ItemInfo{
+			Name: skin.DisplayName,
+			Icon: skin.DisplayIcon,
+			Tier: c.ResolveTier(skin.ContentTierUUID),
+		}, true
+	}
+
+	if chroma, ok := c.Chromas[key]; ok {
+		skin := c.Skins[strings.ToLower(chroma.SkinUUID)]
+		icon := chroma.FullRender
+		if icon == "" {
+			icon = chroma.DisplayIcon
+		}
+		return models.ItemInfo{
+			Name: chroma.DisplayName,
+			Icon: icon,
+			Tier: c.ResolveTier(skin.ContentTierUUID),
+		}, true
+	}
+
+	if level, ok := c.BuddyLevels[key]; ok {
+		buddy := c.Buddies[strings.ToLower(level.BuddyUUID)]
+		return models.ItemInfo{Name: buddy.DisplayName, Icon: buddy.DisplayIcon}, true
+	}
+
+	if buddy, ok := c.Buddies[key]; ok {
+		return models.ItemInfo{Name: buddy.DisplayName, Icon: buddy.DisplayIcon}, true
+	}
+
+	if spray, ok := c.Sprays[key]; ok {
+		icon := spray.FullTransparentIcon
+		if icon == "" {
+			icon = spray.DisplayIcon
+		}
+		return models.ItemInfo{Name: spray.DisplayName, Icon: icon}, true
+	}
+
+	if card, ok := c.PlayerCards[key]; ok {
+		return models.ItemInfo{Name: card.DisplayName, Icon: card.DisplayIcon}, true
+	}
+
+	if bundle, ok := c.Bundles[key]; ok {
+		return models.ItemInfo{Name: bundle.DisplayName, Icon: bundle.DisplayIcon}, true
+	}
+
+	if title, ok := c.PlayerTitles[key]; ok {
+		return models.ItemInfo{Name: title.TitleText}, true
+	}
+
+	if agent, ok := c.Agents[key]; ok {
+		return models.ItemInfo{Name: agent.DisplayName, Icon: agent.DisplayIcon}, true
+	}
+
+	if weapon, ok := c.Weapons[key]; ok {
+		return models.ItemInfo{Name: weapon.DisplayName, Icon: weapon.DisplayIcon}, true
+	}
+
+	return models.ItemInfo{}, false
+}
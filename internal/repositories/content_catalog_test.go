@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/emper0r/val-store-server/internal/models"
+)
+
+func TestResolveTier(t *testing.T) {
+	catalog := &Catalog{
+		ContentTiers: map[string]*models.ContentTier{
+			"tier-premium": {UUID: "tier-premium", DisplayName: "高级版", DevName: "Premium", HighlightColor: "d1548db3"},
+			"tier-select":  {UUID: "tier-select", DevName: "Select", HighlightColor: "5a9fe2"},
+		},
+	}
+
+	tests := []struct {
+		name string
+		uuid string
+		want *models.TierInfo
+	}{
+		{name: "使用本地化名称并去掉透明度", uuid: "TIER-PREMIUM", want: &models.TierInfo{UUID: "tier-premium", Name: "高级版", Color: "#d1548d"}},
+		{name: "缺少本地化名称时使用内部名称", uuid: "tier-select", want: &models.TierInfo{UUID: "tier-select", Name: "Select", Color: "#5a9fe2"}},
+		{name: "品质不存在", uuid: "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := catalog.ResolveTier(tt.uuid)
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("ResolveTier() = %+v，期望%+v", got, tt.want)
+			}
+		})
+	}
+}
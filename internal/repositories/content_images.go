@@ -0,0 +1,70 @@
+package repositories
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/emper0r/val-store-server/internal/models"
+)
+
+// MinimapImage 返回地图的小地图图片，首次使用时下载并缓存到磁盘
+// 小地图不随语言变化，缓存在内容目录之外的minimaps目录中
+func (c *ContentCatalog) MinimapImage(m *models.ContentMap) (image.Image, error) {
+	if m.DisplayIcon == "" {
+		return nil, errors.New("该地图没有小地图")
+	}
+
+	cachePath := filepath.Join(c.cacheDir, "minimaps", strings.ToLower(m.UUID)+".png")
+	data, err := os.ReadFile(cachePath)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Printf("警告: 读取小地图缓存 %s 失败: %v", cachePath, err)
+		}
+
+		data, err = c.downloadFile(m.DisplayIcon)
+		if err != nil {
+			return nil, fmt.Errorf("下载小地图失败: %w", err)
+		}
+
+		if err := writeFileAtomic(cachePath, data); err != nil {
+			log.Printf("警告: 写入小地图缓存 %s 失败: %v", cachePath, err)
+		}
+	}
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("解析小地图失败: %w", err)
+	}
+
+	return img, nil
+}
+
+// downloadFile 下载valorant-api.com的媒体文件
+func (c *ContentCatalog) downloadFile(url string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "val-store-server")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("状态码: %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(resp.Body)
+}
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/emper0r/val-store-server/internal/models"
+)
+
+// GetGameContent 获取Riot内容服务中的赛季和活动信息
+func (rc *RiotClient) GetGameContent() (*models.ValorantContentResponse, error) {
+	var content models.ValorantContentResponse
+	if err := rc.doJSON(http.MethodGet, rc.sharedURL("/content-service/v3/content"), nil, &content); err != nil {
+		return nil, fmt.Errorf("获取赛季信息失败: %w", err)
+	}
+
+	return &content, nil
+}
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/emper0r/val-store-server/internal/models"
+)
+
+// GetContracts 获取玩家的合约进度和任务
+func (rc *RiotClient) GetContracts() (*models.ValorantContractsResponse, error) {
+	var contracts models.ValorantContractsResponse
+	if err := rc.doJSON(http.MethodGet, rc.pdURL("/contracts/v1/contracts/"+rc.puuid), nil, &contracts); err != nil {
+		return nil, fmt.Errorf("获取合约信息失败: %w", err)
+	}
+
+	return &contracts, nil
+}
@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/emper0r/val-store-server/internal/models"
+)
+
+// GetEntitlements 获取玩家拥有的指定类型的物品
+func (rc *RiotClient) GetEntitlements(itemTypeID string) (*models.ValorantItemEntitlementsResponse, error) {
+	var entitlements models.ValorantItemEntitlementsResponse
+	url := rc.pdURL("/store/v1/entitlements/" + rc.puuid + "/" + itemTypeID)
+
+	if err := rc.doJSON(http.MethodGet, url, nil, &entitlements); err != nil {
+		return nil, fmt.Errorf("获取物品权益失败: %w", err)
+	}
+
+	return &entitlements, nil
+}
@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/emper0r/val-store-server/internal/models"
+)
+
+// GetLoadout 获取玩家当前的配置
+func (rc *RiotClient) GetLoadout() (*models.ValorantLoadout, error) {
+	var loadout models.ValorantLoadout
+	url := rc.pdURL("/personalization/v2/players/" + rc.puuid + "/playerloadout")
+
+	if err := rc.doJSON(http.MethodGet, url, nil, &loadout); err != nil {
+		return nil, fmt.Errorf("获取配置失败: %w", err)
+	}
+
+	return &loadout, nil
+}
+
+// PutLoadout 提交完整的配置，返回Riot保存后的配置
+func (rc *RiotClient) PutLoadout(loadout *models.ValorantLoadout) (*models.ValorantLoadout, error) {
+	var updated models.ValorantLoadout
+	url := rc.pdURL("/personalization/v2/players/" + rc.puuid + "/playerloadout")
+
+	if err := rc.doJSON(http.MethodPut, url, loadout, &updated); err != nil {
+		return nil, fmt.Errorf("修改配置失败: %w", err)
+	}
+
+	return &updated, nil
+}
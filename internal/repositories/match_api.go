@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/emper0r/val-store-server/internal/models"
+)
+
+// GetMatchHistory 获取玩家的比赛历史，start和end是从最近一场比赛开始的下标，queue为空时返回所有模式
+func (rc *RiotClient) GetMatchHistory(start, end int, queue string) (*models.ValorantMatchHistoryResponse, error) {
+	query := url.Values{}
+	query.Set("startIndex", strconv.Itoa(start))
+	query.Set("endIndex", strconv.Itoa(end))
+	if queue != "" {
+		query.Set("queue", queue)
+	}
+
+	var history models.ValorantMatchHistoryResponse
+	requestURL := rc.pdURL("/match-history/v1/history/" + rc.puuid + "?" + query.Encode())
+	if err := rc.doJSON(http.MethodGet, requestURL, nil, &history); err != nil {
+		return nil, fmt.Errorf("获取比赛历史失败: %w", err)
+	}
+
+	return &history, nil
+}
+
+// GetMatchDetails 获取比赛详情
+func (rc *RiotClient) GetMatchDetails(matchID string) (*models.ValorantMatchDetails, error) {
+	var details models.ValorantMatchDetails
+	if err := rc.doJSON(http.MethodGet, rc.pdURL("/match-details/v1/matches/"+url.PathEscape(matchID)), nil, &details); err != nil {
+		return nil, fmt.Errorf("获取比赛详情失败: %w", err)
+	}
+
+	return &details, nil
+}
@@ -0,0 +1,220 @@
+package repositories
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+
+	"github.com/emper0r/val-store-server/internal/models"
+)
+
+// ErrMatchNotArchived 存档中没有该比赛
+var ErrMatchNotArchived = errors.New("存档中没有该比赛")
+
+// validMatchID 比赛ID会作为文件名，只允许字母、数字和连字符
+var validMatchID = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+
+// MatchArchive 按用户永久保存比赛详情
+type MatchArchive interface {
+	// Index 返回用户存档中所有比赛的索引，最近的比赛排在前面
+	Index(userID string) ([]models.ArchivedMatch, error)
+	// Save 保存比赛详情并更新索引，已存在的比赛会被覆盖
+	Save(userID string, entry models.ArchivedMatch, details *models.ValorantMatchDetails) error
+	// UpdateIndex 批量替换索引中已有的比赛，只写一次索引，不在存档中的比赛会被忽略
+	UpdateIndex(userID string, entries []models.ArchivedMatch) error
+	// Details 读取存档中的比赛详情，不存在时返回ErrMatchNotArchived
+	Details(userID, matchID string) (*models.ValorantMatchDetails, error)
+}
+
+// FileMatchArchive 基于文件的比赛存档
+// 每个用户一个目录，index.json保存索引，matches目录下每场比赛一个JSON文件
+type FileMatchArchive struct {
+	dir string
+
+	mu      sync.Mutex
+	indexes map[string][]models.ArchivedMatch // 用户ID -> 已加载的索引
+}
+
+// NewFileMatchArchive 创建文件比赛存档
+func NewFileMatchArchive(dir string) (*FileMatchArchive, error) {
+	if dir == "" {
+		return nil, errors.New("比赛存档目录不能为空")
+	}
+
+	return &FileMatchArchive{
+		dir:     dir,
+		indexes: make(map[string][]models.ArchivedMatch),
+	}, nil
+}
+
+// userDir 返回用户的存档目录
+func (a *FileMatchArchive) userDir(userID string) (string, error) {
+	if !validUserID.MatchString(userID) {
+		return "", fmt.Errorf("无效的用户ID: %s", userID)
+	}
+	return filepath.Join(a.dir, userID), nil
+}
+
+// Index 返回用户存档中所有比赛的索引
+func (a *FileMatchArchive) Index(userID string) ([]models.ArchivedMatch, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	index, err := a.indexLocked(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 返回副本，避免调用方修改缓存
+	return append([]models.ArchivedMatch(nil), index...), nil
+}
+
+// Save 先写比赛详情再更新索引，中途失败时索引中不会出现没有详情的比赛
+func (a *FileMatchArchive) Save(userID string, entry models.ArchivedMatch, details *models.ValorantMatchDetails) error {
+	if !validMatchID.MatchString(entry.MatchID) {
+		return fmt.Errorf("无效的比赛ID: %s", entry.MatchID)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	dir, err := a.userDir(userID)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(details)
+	if err != nil {
+		return fmt.Errorf("序列化比赛详情失败: %w", err)
+	}
+	if err := writeFileAtomic(filepath.Join(dir, "matches", entry.MatchID+".json"), data); err != nil {
+		return fmt.Errorf("保存比赛详情失败: %w", err)
+	}
+
+	index, err := a.indexLocked(userID)
+	if err != nil {
+		return err
+	}
+
+	updated := make([]models.ArchivedMatch, 0, len(index)+1)
+	for _, existing := range index {
+		if existing.MatchID != entry.MatchID {
+			updated = append(updated, existing)
+		}
+	}
+	updated = append(updated, entry)
+	sort.SliceStable(updated, func(i, j int) bool {
+		return updated[i].StartTime.After(updated[j].StartTime)
+	})
+
+	return a.writeIndexLocked(userID, dir, updated)
+}
+
+// UpdateIndex 批量替换索引中已有的比赛
+func (a *FileMatchArchive) UpdateIndex(userID string, entries []models.ArchivedMatch) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	dir, err := a.userDir(userID)
+	if err != nil {
+		return err
+	}
+
+	index, err := a.indexLocked(userID)
+	if err != nil {
+		return err
+	}
+
+	replacements := make(map[string]models.ArchivedMatch, len(entries))
+	for _, entry := range entries {
+		replacements[entry.MatchID] = entry
+	}
+
+	updated := make([]models.ArchivedMatch, len(index))
+	for i, existing := range index {
+		if entry, ok := replacements[existing.MatchID]; ok {
+			updated[i] = entry
+		} else {
+			updated[i] = existing
+		}
+	}
+
+	return a.writeIndexLocked(userID, dir, updated)
+}
+
+// writeIndexLocked 写入索引文件并更新缓存，调用方需持有锁
+func (a *FileMatchArchive) writeIndexLocked(userID, dir string, index []models.ArchivedMatch) error {
+	data, err := json.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("序列化比赛索引失败: %w", err)
+	}
+	if err := writeFileAtomic(filepath.Join(dir, "index.json"), data); err != nil {
+		return fmt.Errorf("保存比赛索引失败: %w", err)
+	}
+
+	a.indexes[userID] = index
+	return nil
+}
+
+// Details 读取存档中的比赛详情
+func (a *FileMatchArchive) Details(userID, matchID string) (*models.ValorantMatchDetails, error) {
+	if !validMatchID.MatchString(matchID) {
+		return nil, ErrMatchNotArchived
+	}
+
+	dir, err := a.userDir(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "matches", matchID+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrMatchNotArchived
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取比赛详情失败: %w", err)
+	}
+
+	var details models.ValorantMatchDetails
+	if err := json.Unmarshal(data, &details); err != nil {
+		return nil, fmt.Errorf("解析比赛详情失败: %w", err)
+	}
+
+	return &details, nil
+}
+
+// indexLocked 返回用户的索引，首次访问时从磁盘读取，调用方必须持有锁
+func (a *FileMatchArchive) indexLocked(userID string) ([]models.ArchivedMatch, error) {
+	if index, ok := a.indexes[userID]; ok {
+		return index, nil
+	}
+
+	dir, err := a.userDir(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var index []models.ArchivedMatch
+	data, err := os.ReadFile(filepath.Join(dir, "index.json"))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("读取比赛索引失败: %w", err)
+	default:
+		if err := json.Unmarshal(data, &index); err != nil {
+			return nil, fmt.Errorf("解析比赛索引失败: %w", err)
+		}
+	}
+
+	a.indexes[userID] = index
+	return index, nil
+}
@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/emper0r/val-store-server/internal/models"
+)
+
+// Notifier 发送任务提醒
+type Notifier interface {
+	Notify(reminder *models.MissionReminder) error
+}
+
+// NewNotifier 创建任务提醒的发送方式，未配置Webhook时只记录日志
+func NewNotifier(webhookURL string) Notifier {
+	if webhookURL == "" {
+		return &LogNotifier{}
+	}
+
+	return &WebhookNotifier{
+		url:    webhookURL,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// reminderText 返回提醒的文字内容
+func reminderText(reminder *models.MissionReminder) string {
+	return fmt.Sprintf("%s 还有 %d 个每周任务未完成（共 %d 经验），将在 %s 刷新",
+		reminder.Username, len(reminder.Missions), reminder.RemainingXP, reminder.ResetsAt.Local().Format("2006-01-02 15:04"))
+}
+
+// LogNotifier 将提醒写入日志
+type LogNotifier struct{}
+
+// Notify 记录提醒
+func (n *LogNotifier) Notify(reminder *models.MissionReminder) error {
+	log.Printf("任务提醒: %s", reminderText(reminder))
+	return nil
+}
+
+// WebhookNotifier 将提醒以JSON发送到Webhook
+// content和text字段分别兼容Discord和Slack的Webhook，其余字段供自定义接收方使用
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// Notify 发送提醒
+func (n *WebhookNotifier) Notify(reminder *models.MissionReminder) error {
+	text := reminderText(reminder)
+	payload := struct {
+		Content string `json:"content"`
+		Text    string `json:"text"`
+		*models.MissionReminder
+	}{
+		Content:         text,
+		Text:            text,
+		MissionReminder: reminder,
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("序列化任务提醒失败: %w", err)
+	}
+
+	resp, err := n.client.Post(n.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("发送任务提醒失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("发送任务提醒失败，状态码: %d, 响应: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package repositories
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"strings"
+	"time"
+
+	"github.com/emper0r/val-store-server/internal/models"
+)
+
+// clientPlatform 是Riot要求的X-Riot-ClientPlatform头（Base64编码的平台信息）
+const clientPlatform = "ew0KCSJwbGF0Zm9ybVR5cGUiOiAiUEMiLA0KCSJwbGF0Zm9ybU9TIjogIldpbmRvd3MiLA0KCSJwbGF0Zm9ybU9TVmVyc2lvbiI6ICIxMC4wLjE5MDQyLjEuMjU2LjY0Yml0IiwNCgkicGxhdGZvcm1DaGlwc2V0IjogIlVua25vd24iDQp9"
+
+// RiotClient 单个用户会话专用的Riot API客户端
+// 每个实例拥有独立的cookie jar、区域、分片和令牌，但共享ValorantAPI的Transport
+type RiotClient struct {
+	api         *ValorantAPI
+	client      *http.Client
+	region      string
+	shard       string
+	puuid       string
+	accessToken string
+	entitlement string
+}
+
+// ResolveRegion 将用户输入的区域转换为Riot使用的区域和分片
+func ResolveRegion(region string) (string, string) {
+	// 转小写处理区域代码
+	region = strings.ToLower(strings.TrimSpace(region))
+
+	// 根据API文档更新区域代码映射
+	switch region {
+	case models.RegionNA, models.RegionLATAM, models.RegionBR:
+		// latam和br使用na分片
+		return region, models.RegionNA
+	case models.RegionEU, models.RegionAP, models.RegionKR:
+		return region, region
+	default:
+		// 对于其他输入，使用默认区域
+		return defaultRegion, defaultRegion
+	}
+}
+
+// newRiotClient 创建带有独立cookie jar的会话客户端
+func (v *ValorantAPI) newRiotClient(region string) (*RiotClient, error) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvedRegion, shard := ResolveRegion(region)
+
+	client := &http.Client{
+		Jar:       jar,
+		Timeout:   30 * time.Second,
+		Transport: v.transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			// 禁止自动跟随重定向
+			return http.ErrUseLastResponse
+		},
+	}
+
+	return &RiotClient{
+		api:    v,
+		client: client,
+		region: resolvedRegion,
+		shard:  shard,
+	}, nil
+}
+
+// NewClient 根据已登录的用户会话创建专用的Riot API客户端
+func (v *ValorantAPI) NewClient(session *models.UserSession) (*RiotClient, error) {
+	if session == nil || session.AccessToken == "" || session.Entitlement == "" {
+		return nil, errors.New("会话中缺少Riot令牌")
+	}
+
+	rc, err := v.newRiotClient(session.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	rc.puuid = session.UserID
+	rc.accessToken = session.AccessToken
+	rc.entitlement = session.Entitlement
+
+	return rc, nil
+}
+
+// Region 返回客户端使用的区域
+func (rc *RiotClient) Region() string {
+	return rc.region
+}
+
+// Shard 返回客户端使用的分片
+func (rc *RiotClient) Shard() string {
+	return rc.shard
+}
+
+// PUUID 返回当前登录玩家的ID
+func (rc *RiotClient) PUUID() string {
+	return rc.puuid
+}
+
+// pdURL 构建分片对应的pd服务地址
+func (rc *RiotClient) pdURL(path string) string {
+	return fmt.Sprintf(rc.api.endpoints.pdURLFormat, rc.shard) + path
+}
+
+// sharedURL 构建分片对应的shared服务地址
+func (rc *RiotClient) sharedURL(path string) string {
+	return fmt.Sprintf(rc.api.endpoints.sharedURLFormat, rc.shard) + path
+}
+
+// setSessionHeaders 设置调用游戏服务所需的会话请求头
+func (rc *RiotClient) setSessionHeaders(req *http.Request) {
+	req.Header.Set("Authorization", "Bearer "+rc.accessToken)
+	req.Header.Set("X-Riot-Entitlements-JWT", rc.entitlement)
+	req.Header.Set("X-Riot-ClientVersion", rc.api.clientVersion)
+	req.Header.Set("X-Riot-ClientPlatform", clientPlatform)
+	req.Header.Set("Content-Type", "application/json")
+}
+
+// doJSON 使用会话令牌发送请求，并将JSON响应解析到out中
+func (rc *RiotClient) doJSON(method, url string, body interface{}, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("序列化请求体失败: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		return fmt.Errorf("创建请求失败: %w", err)
+	}
+	rc.setSessionHeaders(req)
+
+	resp, err := rc.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("发送请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("请求失败，状态码: %d, 响应: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("解析响应失败: %w", err)
+	}
+
+	return nil
+}
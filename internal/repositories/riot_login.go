@@ -0,0 +1,261 @@
+package repositories
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/emper0r/val-store-server/internal/models"
+)
+
+// 账号密码登录的错误
+var (
+	ErrInvalidCredentials = errors.New("用户名或密码错误")
+	ErrInvalidMFACode     = errors.New("验证码错误")
+	ErrLoginRateLimited   = errors.New("登录请求过于频繁，请稍后再试")
+)
+
+// riotAuthResponse Riot登录接口的响应，type为success、multifactor或auth
+type riotAuthResponse struct {
+	Type    string `json:"type"`
+	Error   string `json:"error"`
+	Success struct {
+		LoginToken string `json:"login_token"`
+	} `json:"success"`
+	Multifactor struct {
+		Email   string   `json:"email"`
+		Method  string   `json:"method"`
+		Methods []string `json:"methods"`
+	} `json:"multifactor"`
+	Captcha struct {
+		Type     string `json:"type"`
+		Hcaptcha struct {
+			Key  string `json:"key"`
+			Data string `json:"data"`
+		} `json:"hcaptcha"`
+	} `json:"captcha"`
+}
+
+// captchaChallenge 返回响应中的人机验证信息，没有时返回nil
+func (r *riotAuthResponse) captchaChallenge() *models.CaptchaChallenge {
+	if r.Captcha.Type == "" {
+		return nil
+	}
+	return &models.CaptchaChallenge{
+		Provider: r.Captcha.Type,
+		SiteKey:  r.Captcha.Hcaptcha.Key,
+		Data:     r.Captcha.Hcaptcha.Data,
+	}
+}
+
+// PasswordLoginResult 提交账号密码或验证码后的结果
+// 登录成功时Session不为空，否则需要完成MFA或Captcha中的挑战
+type PasswordLoginResult struct {
+	Session *models.UserSession
+	MFA     *models.MFAChallenge
+	Captcha *models.CaptchaChallenge
+}
+
+// PasswordLogin 一次账号密码登录流程，多次请求之间共享同一个cookie jar
+type PasswordLogin struct {
+	rc *RiotClient
+}
+
+// StartPasswordLogin 开始账号密码登录，为本次登录创建独立的会话客户端
+// Riot要求人机验证时返回验证信息，客户端完成验证后再提交账号密码
+func (v *ValorantAPI) StartPasswordLogin(region string) (*PasswordLogin, *models.CaptchaChallenge, error) {
+	rc, err := v.newRiotClient(region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 获取授权域名下的Cookie
+	authorization := map[string]string{
+		"client_id":     "play-valorant-web-prod",
+		"nonce":         "1",
+		"redirect_uri":  "https://playvalorant.com/opt_in",
+		"response_type": "token id_token",
+		"scope":         "account openid",
+	}
+	if err := rc.doAuthRequest(http.MethodPost, v.endpoints.loginURL, authorization, nil); err != nil {
+		return nil, nil, fmt.Errorf("获取授权Cookie失败: %w", err)
+	}
+
+	// 开始登录，Riot在响应中下发人机验证的参数
+	body := map[string]interface{}{
+		"clientId": "riot-client",
+		"language": "",
+		"platform": "windows",
+		"remember": false,
+		"riot_identity": map[string]string{
+			"language": "en_US",
+			"state":    "auth",
+		},
+		"type": "auth",
+	}
+	var authResp riotAuthResponse
+	if err := rc.doAuthRequest(http.MethodPost, v.endpoints.authenticateURL, body, &authResp); err != nil {
+		return nil, nil, fmt.Errorf("开始登录失败: %w", err)
+	}
+
+	return &PasswordLogin{rc: rc}, authResp.captchaChallenge(), nil
+}
+
+// SubmitCredentials 提交用户名和密码，captchaToken为客户端完成人机验证后得到的令牌
+func (l *PasswordLogin) SubmitCredentials(username, password, captchaToken string) (*PasswordLoginResult, error) {
+	identity := map[string]interface{}{
+		"username": username,
+		"password": password,
+		"remember": true, // 让Riot下发ssid，供后续重新认证
+		"language": "en_US",
+	}
+	if captchaToken != "" {
+		identity["captcha"] = "hcaptcha " + captchaToken
+	}
+	body := map[string]interface{}{
+		"type":          "auth",
+		"riot_identity": identity,
+	}
+
+	var authResp riotAuthResponse
+	if err := l.rc.doAuthRequest(http.MethodPut, l.rc.api.endpoints.authenticateURL, body, &authResp); err != nil {
+		return nil, err
+	}
+
+	return l.handleAuthResponse(&authResp)
+}
+
+// SubmitMFA 提交邮箱验证码
+func (l *PasswordLogin) SubmitMFA(code string, rememberDevice bool) (*PasswordLoginResult, error) {
+	body := map[string]interface{}{
+		"type": "multifactor",
+		"multifactor": map[string]interface{}{
+			"otp":            code,
+			"rememberDevice": rememberDevice,
+		},
+	}
+
+	var authResp riotAuthResponse
+	if err := l.rc.doAuthRequest(http.MethodPut, l.rc.api.endpoints.authenticateURL, body, &authResp); err != nil {
+		return nil, err
+	}
+
+	return l.handleAuthResponse(&authResp)
+}
+
+// handleAuthResponse 根据登录接口的响应完成登录或返回需要用户处理的挑战
+func (l *PasswordLogin) handleAuthResponse(authResp *riotAuthResponse) (*PasswordLoginResult, error) {
+	switch authResp.Error {
+	case "":
+	case "auth_failure":
+		return nil, ErrInvalidCredentials
+	case "multifactor_attempt_failed":
+		return nil, ErrInvalidMFACode
+	case "rate_limited":
+		return nil, ErrLoginRateLimited
+	default:
+		// 人机验证未通过时Riot会下发新的验证参数
+		if captcha := authResp.captchaChallenge(); captcha != nil {
+			return &PasswordLoginResult{Captcha: captcha}, nil
+		}
+		return nil, fmt.Errorf("登录失败: %s", authResp.Error)
+	}
+
+	switch authResp.Type {
+	case "success":
+		session, err := l.exchangeLoginToken(authResp.Success.LoginToken)
+		if err != nil {
+			return nil, err
+		}
+		return &PasswordLoginResult{Session: session}, nil
+	case "multifactor":
+		return &PasswordLoginResult{
+			MFA: &models.MFAChallenge{
+				Email:  authResp.Multifactor.Email,
+				Method: authResp.Multifactor.Method,
+			},
+		}, nil
+	case "auth":
+		if captcha := authResp.captchaChallenge(); captcha != nil {
+			return &PasswordLoginResult{Captcha: captcha}, nil
+		}
+		return nil, errors.New("登录响应中没有人机验证信息")
+	default:
+		return nil, fmt.Errorf("未知的登录响应类型: %s", authResp.Type)
+	}
+}
+
+// exchangeLoginToken 用登录令牌换取ssid等Cookie，再使用Cookie完成认证
+func (l *PasswordLogin) exchangeLoginToken(loginToken string) (*models.UserSession, error) {
+	if loginToken == "" {
+		return nil, errors.New("登录响应中没有登录令牌")
+	}
+
+	body := map[string]interface{}{
+		"authentication_type": "RiotAuth",
+		"code_verifier":       "",
+		"login_token":         loginToken,
+		"persist_login":       true,
+	}
+	if err := l.rc.doAuthRequest(http.MethodPost, l.rc.api.endpoints.loginTokenURL, body, nil); err != nil {
+		return nil, fmt.Errorf("兑换登录令牌失败: %w", err)
+	}
+
+	// 从cookie jar中收集ssid等Cookie，以便后续使用Cookie重新认证
+	cookies := mergeRotatedCookies(nil, l.rc.authCookies())
+	return l.rc.authenticateWithCookies(cookies)
+}
+
+// doAuthRequest 向Riot登录接口发送JSON请求，Cookie由会话客户端的cookie jar维护
+// out为nil时忽略响应体
+func (rc *RiotClient) doAuthRequest(method, endpoint string, body, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("序列化请求体失败: %w", err)
+	}
+
+	req, err := http.NewRequest(method, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("创建请求失败: %w", err)
+	}
+	rc.setRiotRequestHeaders(req, nil)
+	// 手动设置Accept-Encoding会关闭Transport的自动解压，这里需要读取响应体
+	req.Header.Del("Accept-Encoding")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := rc.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("发送请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return ErrLoginRateLimited
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("登录请求失败，状态码: %d, 响应: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("解析登录响应失败: %w", err)
+	}
+
+	return nil
+}
+
+// authCookies 返回cookie jar中授权域名下的Cookie
+func (rc *RiotClient) authCookies() []*http.Cookie {
+	u, err := url.Parse(rc.api.endpoints.authorizeURL)
+	if err != nil {
+		return nil
+	}
+	return rc.client.Jar.Cookies(u)
+}
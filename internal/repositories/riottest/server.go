@@ -0,0 +1,60 @@
+// Package riottest 提供模拟Riot服务的本地服务器，供各层的测试使用
+// 配合repositories.NewValorantAPIWithBaseURL使用
+package riottest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/emper0r/val-store-server/internal/models"
+)
+
+// NewServer 创建模拟Riot认证和pd服务的本地服务器，测试结束时自动关闭
+// 令牌都由ssid派生，方便校验并发登录之间没有串号
+func NewServer(t testing.TB) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/authorize", func(w http.ResponseWriter, r *http.Request) {
+		ssid, err := r.Cookie("ssid")
+		if err != nil || ssid.Value == "" {
+			w.Header().Set("Location", "https://authenticate.riotgames.com/login")
+			w.WriteHeader(http.StatusSeeOther)
+			return
+		}
+		// 模拟Riot轮换ssid和clid
+		if ssid.Value == "rotate" {
+			http.SetCookie(w, &http.Cookie{Name: "ssid", Value: "rotated-ssid"})
+			http.SetCookie(w, &http.Cookie{Name: "clid", Value: "rotated-clid"})
+		}
+		w.Header().Set("Location", "https://playvalorant.com/opt_in#access_token=at-"+ssid.Value+"&scope=account&expires_in=3600")
+		w.WriteHeader(http.StatusSeeOther)
+	})
+	mux.HandleFunc("/entitlements", func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer at-")
+		json.NewEncoder(w).Encode(models.ValorantEntitlementResponse{EntitlementToken: "ent-" + token})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer at-")
+		var info models.ValorantUserInfoResponse
+		info.Sub = "puuid-" + token
+		info.Acct.GameName = "player-" + token
+		info.Acct.TagLine = "tag"
+		json.NewEncoder(w).Encode(info)
+	})
+	mux.HandleFunc("/pd/", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"path":        r.URL.Path,
+			"auth":        r.Header.Get("Authorization"),
+			"entitlement": r.Header.Get("X-Riot-Entitlements-JWT"),
+			"version":     r.Header.Get("X-Riot-ClientVersion"),
+		})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
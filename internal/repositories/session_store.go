@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/emper0r/val-store-server/internal/models"
+)
+
+// 会话存储类型
+const (
+	SessionStoreMemory = "memory" // 内存存储，重启后会话丢失
+	SessionStoreFile   = "file"   // 文件存储，重启后会话仍然有效
+)
+
+// ErrSessionNotFound 会话不存在或已过期
+var ErrSessionNotFound = errors.New("会话不存在或已过期")
+
+// SessionStore 以JWT的jti为键保存用户的Riot会话
+type SessionStore interface {
+	// Save 保存或覆盖会话
+	Save(id string, session *models.UserSession) error
+	// Get 获取会话，不存在或已过期时返回ErrSessionNotFound
+	Get(id string) (*models.UserSession, error)
+	// Delete 删除会话
+	Delete(id string) error
+	// All 返回所有未过期会话的副本，键为会话ID
+	All() (map[string]*models.UserSession, error)
+}
+
+// NewSessionStore 根据存储类型创建会话存储
+func NewSessionStore(kind, filePath string) (SessionStore, error) {
+	switch kind {
+	case "", SessionStoreMemory:
+		return NewMemorySessionStore(), nil
+	case SessionStoreFile:
+		return NewFileSessionStore(filePath)
+	default:
+		return nil, fmt.Errorf("未知的会话存储类型: %s", kind)
+	}
+}
+
+// MemorySessionStore 基于内存的会话存储
+type MemorySessionStore struct {
+	mu       sync.RWMutex
+	sessions map[string]*models.UserSession
+}
+
+// NewMemorySessionStore 创建新的内存会话存储
+func NewMemorySessionStore() *MemorySessionStore {
+	return &MemorySessionStore{
+		sessions: make(map[string]*models.UserSession),
+	}
+}
+
+// Save 保存会话的副本
+func (s *MemorySessionStore) Save(id string, session *models.UserSession) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pruneLocked(time.Now())
+	s.sessions[id] = copySession(session)
+	return nil
+}
+
+// Get 获取会话的副本
+func (s *MemorySessionStore) Get(id string) (*models.UserSession, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	session, ok := s.sessions[id]
+	if !ok || isSessionExpired(session, time.Now()) {
+		return nil, ErrSessionNotFound
+	}
+	return copySession(session), nil
+}
+
+// Delete 删除会话
+func (s *MemorySessionStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.sessions, id)
+	return nil
+}
+
+// All 返回所有未过期会话的副本
+func (s *MemorySessionStore) All() (map[string]*models.UserSession, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	sessions := make(map[string]*models.UserSession, len(s.sessions))
+	for id, session := range s.sessions {
+		if !isSessionExpired(session, now) {
+			sessions[id] = copySession(session)
+		}
+	}
+	return sessions, nil
+}
+
+// pruneLocked 清理已过期的会话，调用方必须持有写锁
+func (s *MemorySessionStore) pruneLocked(now time.Time) {
+	for id, session := range s.sessions {
+		if isSessionExpired(session, now) {
+			delete(s.sessions, id)
+		}
+	}
+}
+
+// isSessionExpired 判断会话是否已过期
+func isSessionExpired(session *models.UserSession, now time.Time) bool {
+	return !session.ExpiresAt.IsZero() && now.After(session.ExpiresAt)
+}
+
+// copySession 深拷贝会话，避免调用方修改存储中的数据
+func copySession(session *models.UserSession) *models.UserSession {
+	copied := *session
+	if session.Cookies != nil {
+		copied.Cookies = make(map[string]string, len(session.Cookies))
+		for name, value := range session.Cookies {
+			copied.Cookies[name] = value
+		}
+	}
+	return &copied
+}
@@ -0,0 +1,143 @@
+package repositories
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/emper0r/val-store-server/internal/models"
+)
+
+// persistedSession 写入文件的会话格式
+// UserSession中的Cookie默认不会被序列化，这里需要显式保存以便重新认证
+type persistedSession struct {
+	*models.UserSession
+	Cookies map[string]string `json:"cookies"`
+}
+
+// FileSessionStore 基于JSON文件的会话存储
+// 所有会话同时保存在内存中，每次修改后整体写回文件
+type FileSessionStore struct {
+	*MemorySessionStore
+	path string
+}
+
+// NewFileSessionStore 创建文件会话存储，并加载已有的会话
+func NewFileSessionStore(path string) (*FileSessionStore, error) {
+	if path == "" {
+		return nil, errors.New("会话文件路径不能为空")
+	}
+
+	store := &FileSessionStore{
+		MemorySessionStore: NewMemorySessionStore(),
+		path:               path,
+	}
+
+	if err := store.load(); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// Save 保存会话并写回文件
+func (s *FileSessionStore) Save(id string, session *models.UserSession) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pruneLocked(time.Now())
+	s.sessions[id] = copySession(session)
+	return s.flushLocked()
+}
+
+// Delete 删除会话并写回文件
+func (s *FileSessionStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.sessions, id)
+	return s.flushLocked()
+}
+
+// load 从文件中读取会话
+func (s *FileSessionStore) load() error {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("读取会话文件失败: %w", err)
+	}
+
+	var persisted map[string]persistedSession
+	if err := json.Unmarshal(data, &persisted); err != nil {
+		return fmt.Errorf("解析会话文件失败: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, p := range persisted {
+		if p.UserSession == nil {
+			continue
+		}
+		p.UserSession.Cookies = p.Cookies
+		s.sessions[id] = p.UserSession
+	}
+	s.pruneLocked(time.Now())
+
+	return nil
+}
+
+// flushLocked 将所有会话写入文件，调用方必须持有写锁
+func (s *FileSessionStore) flushLocked() error {
+	persisted := make(map[string]persistedSession, len(s.sessions))
+	for id, session := range s.sessions {
+		persisted[id] = persistedSession{UserSession: session, Cookies: session.Cookies}
+	}
+
+	data, err := json.Marshal(persisted)
+	if err != nil {
+		return fmt.Errorf("序列化会话失败: %w", err)
+	}
+
+	return writeFileAtomic(s.path, data)
+}
+
+// writeFileAtomic 先写入临时文件再重命名，避免写入中断导致文件损坏
+// 文件中包含令牌和Cookie，因此只允许当前用户读写
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("创建目录失败: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("创建临时文件失败: %w", err)
+	}
+	tmpPath := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("写入临时文件失败: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("关闭临时文件失败: %w", err)
+	}
+	if err := os.Chmod(tmpPath, 0o600); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("设置文件权限失败: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("替换文件失败: %w", err)
+	}
+
+	return nil
+}
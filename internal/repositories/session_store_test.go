@@ -0,0 +1,194 @@
+package repositories
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/emper0r/val-store-server/internal/models"
+)
+
+// newTestSession 创建带Cookie的会话，expiresIn为0时永不过期
+func newTestSession(userID string, expiresIn time.Duration) *models.UserSession {
+	session := &models.UserSession{
+		UserID:      userID,
+		AccessToken: "at-" + userID,
+		Cookies:     map[string]string{"ssid": "ssid-" + userID},
+	}
+	if expiresIn != 0 {
+		session.ExpiresAt = time.Now().Add(expiresIn)
+	}
+	return session
+}
+
+func TestSessionStores(t *testing.T) {
+	stores := map[string]func(t *testing.T) SessionStore{
+		SessionStoreMemory: func(t *testing.T) SessionStore {
+			return NewMemorySessionStore()
+		},
+		SessionStoreFile: func(t *testing.T) SessionStore {
+			store, err := NewFileSessionStore(filepath.Join(t.TempDir(), "sessions.json"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			return store
+		},
+	}
+
+	for kind, newStore := range stores {
+		t.Run(kind, func(t *testing.T) {
+			store := newStore(t)
+
+			if _, err := store.Get("missing"); !errors.Is(err, ErrSessionNotFound) {
+				t.Errorf("错误为%v，期望%v", err, ErrSessionNotFound)
+			}
+
+			session := newTestSession("alice", time.Hour)
+			if err := store.Save("s1", session); err != nil {
+				t.Fatal(err)
+			}
+			if err := store.Save("s2", newTestSession("bob", 0)); err != nil {
+				t.Fatal(err)
+			}
+
+			// 修改调用方持有的会话不影响存储中的副本
+			session.Cookies["ssid"] = "changed"
+			got, err := store.Get("s1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.UserID != "alice" || got.Cookies["ssid"] != "ssid-alice" {
+				t.Errorf("会话为%+v", got)
+			}
+			got.Cookies["ssid"] = "changed"
+			if again, _ := store.Get("s1"); again.Cookies["ssid"] != "ssid-alice" {
+				t.Errorf("Get返回的不是副本: %+v", again)
+			}
+
+			all, err := store.All()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(all) != 2 || all["s2"].UserID != "bob" {
+				t.Errorf("All()返回%+v", all)
+			}
+
+			if err := store.Delete("s1"); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := store.Get("s1"); !errors.Is(err, ErrSessionNotFound) {
+				t.Errorf("删除后错误为%v，期望%v", err, ErrSessionNotFound)
+			}
+		})
+	}
+}
+
+func TestMemorySessionStoreExpiry(t *testing.T) {
+	store := NewMemorySessionStore()
+	if err := store.Save("expired", newTestSession("alice", -time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.Get("expired"); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("错误为%v，期望%v", err, ErrSessionNotFound)
+	}
+	if all, _ := store.All(); len(all) != 0 {
+		t.Errorf("All()不应返回过期会话: %+v", all)
+	}
+
+	// 下一次保存时清理过期会话
+	if err := store.Save("live", newTestSession("bob", time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := store.sessions["expired"]; ok || len(store.sessions) != 1 {
+		t.Errorf("过期会话没有被清理: %+v", store.sessions)
+	}
+}
+
+func TestFileSessionStoreReload(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sessions.json")
+
+	store, err := NewFileSessionStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save("live", newTestSession("alice", time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save("gone", newTestSession("bob", time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Delete("gone"); err != nil {
+		t.Fatal(err)
+	}
+
+	// 文件中包含令牌和Cookie，只允许当前用户读写
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0o600 {
+		t.Errorf("文件权限为%o，期望600", mode)
+	}
+
+	// 原子写入后不应残留临时文件
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("目录中有%d个文件，期望只有会话文件", len(entries))
+	}
+
+	reloaded, err := NewFileSessionStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := reloaded.Get("live")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.UserID != "alice" || got.AccessToken != "at-alice" || got.Cookies["ssid"] != "ssid-alice" {
+		t.Errorf("重新加载的会话为%+v", got)
+	}
+	if _, err := reloaded.Get("gone"); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("已删除的会话被重新加载: %v", err)
+	}
+}
+
+func TestFileSessionStoreDropsExpiredOnLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.json")
+	expired := newTestSession("alice", -time.Minute)
+	data, err := json.Marshal(map[string]persistedSession{
+		"expired": {UserSession: expired, Cookies: expired.Cookies},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := NewFileSessionStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(store.sessions) != 0 {
+		t.Errorf("加载时没有清理过期会话: %+v", store.sessions)
+	}
+}
+
+func TestFileSessionStoreRejectsCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.json")
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewFileSessionStore(path); err == nil {
+		t.Error("损坏的会话文件应返回错误")
+	}
+}
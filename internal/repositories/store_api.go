@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/emper0r/val-store-server/internal/models"
+)
+
+// GetStorefront 获取玩家的商店信息
+func (rc *RiotClient) GetStorefront() (*models.StorefrontResponse, error) {
+	var storefront models.StorefrontResponse
+	url := rc.pdURL("/store/v3/storefront/" + rc.puuid)
+
+	// v3接口要求POST一个空的JSON对象
+	if err := rc.doJSON(http.MethodPost, url, struct{}{}, &storefront); err != nil {
+		return nil, fmt.Errorf("获取商店信息失败: %w", err)
+	}
+
+	return &storefront, nil
+}
+
+// GetWallet 获取玩家的钱包余额
+func (rc *RiotClient) GetWallet() (*models.ValorantWalletResponse, error) {
+	var wallet models.ValorantWalletResponse
+	if err := rc.doJSON(http.MethodGet, rc.pdURL("/store/v1/wallet/"+rc.puuid), nil, &wallet); err != nil {
+		return nil, fmt.Errorf("获取钱包信息失败: %w", err)
+	}
+
+	return &wallet, nil
+}
@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+
+	"github.com/emper0r/val-store-server/internal/models"
+)
+
+// validUserID 用户ID会作为文件名，只允许字母、数字和连字符
+var validUserID = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+
+// UserSettingsStore 按用户ID保存用户设置
+type UserSettingsStore interface {
+	// Get 获取用户设置，用户没有保存过设置时返回空设置
+	Get(userID string) (*models.UserSettings, error)
+	// Update 读取、修改并保存用户设置，fn返回错误时不保存
+	Update(userID string, fn func(settings *models.UserSettings) error) error
+}
+
+// FileUserSettingsStore 基于文件的用户设置存储，每个用户一个JSON文件
+type FileUserSettingsStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewFileUserSettingsStore 创建文件用户设置存储
+func NewFileUserSettingsStore(dir string) (*FileUserSettingsStore, error) {
+	if dir == "" {
+		return nil, errors.New("用户设置目录不能为空")
+	}
+
+	return &FileUserSettingsStore{dir: dir}, nil
+}
+
+// path 返回用户设置文件的路径
+func (s *FileUserSettingsStore) path(userID string) (string, error) {
+	if !validUserID.MatchString(userID) {
+		return "", fmt.Errorf("无效的用户ID: %s", userID)
+	}
+	return filepath.Join(s.dir, userID+".json"), nil
+}
+
+// Get 获取用户设置
+func (s *FileUserSettingsStore) Get(userID string) (*models.UserSettings, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.readLocked(userID)
+}
+
+// Update 在锁内完成读取、修改和保存，避免并发修改互相覆盖
+func (s *FileUserSettingsStore) Update(userID string, fn func(settings *models.UserSettings) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	settings, err := s.readLocked(userID)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(settings); err != nil {
+		return err
+	}
+
+	path, err := s.path(userID)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("序列化用户设置失败: %w", err)
+	}
+
+	return writeFileAtomic(path, data)
+}
+
+// readLocked 读取用户设置，调用方必须持有锁
+func (s *FileUserSettingsStore) readLocked(userID string) (*models.UserSettings, error) {
+	path, err := s.path(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &models.UserSettings{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取用户设置失败: %w", err)
+	}
+
+	var settings models.UserSettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("解析用户设置失败: %w", err)
+	}
+
+	return &settings, nil
+}
@@ -9,7 +9,7 @@ import (
 	"log"
 	"net"
 	"net/http"
-	"net/http/cookiejar"
+	"strconv"
 	"strings"
 	"time"
 
@@ -19,14 +19,52 @@ import (
 const (
 	// API URLs
 	loginURL        = "https://auth.riotgames.com/api/v1/authorization"
+	authenticateURL = "https://authenticate.riotgames.com/api/v1/login"
+	loginTokenURL   = "https://auth.riotgames.com/api/v1/login-token"
+	authorizeURL    = "https://auth.riotgames.com/authorize?redirect_uri=https%3A%2F%2Fplayvalorant.com%2Fopt_in&client_id=play-valorant-web-prod&response_type=token%20id_token&scope=account%20openid&nonce=1"
 	entitlementsURL = "https://entitlements.auth.riotgames.com/api/token/v1"
 	userInfoURL     = "https://auth.riotgames.com/userinfo"
 	versionURL      = "https://valorant-api.com/v1/version"
+	pdURLFormat     = "https://pd.%s.a.pvp.net"
+	sharedURLFormat = "https://shared.%s.a.pvp.net"
 
 	// 默认区域
 	defaultRegion = "ap"
+
+	// 访问令牌默认有效期，Location中没有expires_in时使用
+	defaultTokenLifetime = time.Hour
 )
 
+// ErrCookiesExpired 表示Cookie已失效，授权流程被重定向到登录页
+var ErrCookiesExpired = errors.New("Cookie无效或已过期")
+
+// essentialCookieNames 认证所需的关键Cookie
+var essentialCookieNames = []string{"ssid", "csid", "clid", "sub", "tdid", "asid", "did"}
+
+// riotEndpoints 描述Riot各服务的地址，便于在测试中替换为本地服务
+type riotEndpoints struct {
+	loginURL        string
+	authenticateURL string // 提交账号密码和验证码
+	loginTokenURL   string // 用登录令牌换取认证Cookie
+	authorizeURL    string
+	entitlementsURL string
+	userInfoURL     string
+	pdURLFormat     string // 以分片名格式化，例如 https://pd.%s.a.pvp.net
+	sharedURLFormat string // 以分片名格式化，例如 https://shared.%s.a.pvp.net
+}
+
+// defaultEndpoints 生产环境使用的Riot服务地址
+var defaultEndpoints = riotEndpoints{
+	loginURL:        loginURL,
+	authenticateURL: authenticateURL,
+	loginTokenURL:   loginTokenURL,
+	authorizeURL:    authorizeURL,
+	entitlementsURL: entitlementsURL,
+	userInfoURL:     userInfoURL,
+	pdURLFormat:     pdURLFormat,
+	sharedURLFormat: sharedURLFormat,
+}
+
 // 用于解析版本API响应的结构体
 type versionResponse struct {
 	Status int `json:"status"`
@@ -36,10 +74,11 @@ type versionResponse struct {
 }
 
 // ValorantAPI 处理与Valorant API的交互
+// 它本身不保存任何用户状态，只负责创建会话专用的RiotClient
 type ValorantAPI struct {
-	client        *http.Client
-	region        string
+	transport     *http.Transport
 	clientVersion string
+	endpoints     riotEndpoints
 }
 
 // fetchLatestClientVersion 从valorant-api.com获取最新的客户端版本
@@ -73,21 +112,15 @@ func fetchLatestClientVersion(client *http.Client) (string, error) {
 	return versionData.Data.RiotClientVersion, nil
 }
 
-// NewValorantAPI 创建一个新的ValorantAPI实例
-func NewValorantAPI() (*ValorantAPI, error) {
-	// 创建带cookie jar的HTTP客户端，以便能够维护会话
-	jar, err := cookiejar.New(nil)
-	if err != nil {
-		return nil, err
-	}
-
+// newRiotTransport 创建所有Riot请求共享的Transport，保留代理、TLS和连接池设置
+func newRiotTransport() *http.Transport {
 	// TLS配置，提高安全性和兼容性
 	tlsConfig := &tls.Config{
 		MinVersion: tls.VersionTLS12,
 	}
 
 	// 创建具有更健壮配置的Transport
-	transport := &http.Transport{
+	return &http.Transport{
 		Proxy: http.ProxyFromEnvironment,
 		DialContext: (&net.Dialer{
 			Timeout:   30 * time.Second,
@@ -102,12 +135,11 @@ func NewValorantAPI() (*ValorantAPI, error) {
 		ResponseHeaderTimeout: 30 * time.Second,
 		TLSClientConfig:       tlsConfig,
 	}
+}
 
-	client := &http.Client{
-		Jar:       jar,
-		Timeout:   60 * time.Second, // 增加超时时间到60秒
-		Transport: transport,
-	}
+// NewValorantAPI 创建一个新的ValorantAPI实例
+func NewValorantAPI() (*ValorantAPI, error) {
+	transport := newRiotTransport()
 
 	// 创建一个用于获取版本的临时客户端
 	versionClient := &http.Client{
@@ -128,39 +160,35 @@ func NewValorantAPI() (*ValorantAPI, error) {
 	}
 
 	api := &ValorantAPI{
-		client:        client,
-		region:        defaultRegion,
+		transport:     transport,
 		clientVersion: currentClientVersion,
+		endpoints:     defaultEndpoints,
 	}
 
 	return api, nil
 }
 
-// SetRegion 设置用户的地区
-func (v *ValorantAPI) SetRegion(region string) {
-	if region == "" {
-		v.region = defaultRegion
-		return
-	}
-
-	// 转小写处理区域代码
-	region = strings.ToLower(region)
-
-	// 根据API文档更新区域代码映射
-	switch region {
-	case "na", "latam", "br":
-		// latam和br使用na区域
-		v.region = "na"
-	case "eu":
-		v.region = "eu"
-	case "ap", "kr":
-		v.region = region
-	default:
-		// 对于其他输入，使用默认区域
-		v.region = defaultRegion
+// NewValorantAPIWithBaseURL 创建所有Riot服务都指向baseURL的ValorantAPI，用于测试和本地模拟服务
+func NewValorantAPIWithBaseURL(baseURL, clientVersion string) *ValorantAPI {
+	return &ValorantAPI{
+		transport:     newRiotTransport(),
+		clientVersion: clientVersion,
+		endpoints: riotEndpoints{
+			loginURL:        baseURL + "/login",
+			authenticateURL: baseURL + "/authenticate",
+			loginTokenURL:   baseURL + "/login-token",
+			authorizeURL:    baseURL + "/authorize",
+			entitlementsURL: baseURL + "/entitlements",
+			userInfoURL:     baseURL + "/userinfo",
+			pdURLFormat:     baseURL + "/pd/%s",
+			sharedURLFormat: baseURL + "/shared/%s",
+		},
 	}
+}
 
-	log.Printf("区域设置已更新: 输入=%s, 最终区域=%s\n", region, v.region)
+// ClientVersion 返回当前使用的Riot客户端版本
+func (v *ValorantAPI) ClientVersion() string {
+	return v.clientVersion
 }
 
 // ParseCookieString 解析Cookie字符串为map
@@ -231,11 +259,8 @@ func ParseCookieString(cookieStr string) map[string]string {
 func FilterEssentialCookies(cookies map[string]string) map[string]string {
 	essentialCookies := make(map[string]string)
 
-	// 优先检查的关键Cookie
-	keyCookies := []string{"ssid", "csid", "clid", "sub", "tdid", "asid", "did"}
-
 	// 检查并添加关键Cookie
-	for _, key := range keyCookies {
+	for _, key := range essentialCookieNames {
 		if value, exists := cookies[key]; exists && value != "" {
 			essentialCookies[key] = value
 		}
@@ -249,52 +274,44 @@ func FilterEssentialCookies(cookies map[string]string) map[string]string {
 	return essentialCookies
 }
 
-// AuthenticateWithCookies 使用Cookie进行认证
-func (v *ValorantAPI) AuthenticateWithCookies(cookies map[string]string) (*models.UserSession, error) {
+// AuthenticateWithCookies 使用Cookie进行认证，每次登录都使用独立的会话客户端
+func (v *ValorantAPI) AuthenticateWithCookies(cookies map[string]string, region string) (*models.UserSession, error) {
 	// 过滤保留有用的Cookie
 	filteredCookies := FilterEssentialCookies(cookies)
 	if len(filteredCookies) == 0 {
 		return nil, errors.New("没有提供任何有效的Cookie")
 	}
 
-	// 创建带有cookie jar的新HTTP客户端
-	jar, err := cookiejar.New(nil)
+	// 为本次登录创建独立的客户端，避免并发登录互相覆盖cookie jar和区域
+	rc, err := v.newRiotClient(region)
 	if err != nil {
 		return nil, err
 	}
 
-	// 禁用重定向的客户端
-	client := &http.Client{
-		Jar:     jar,
-		Timeout: 30 * time.Second,
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			// 禁止自动跟随重定向
-			return http.ErrUseLastResponse
-		},
-	}
-
-	// 使用这个带cookie的客户端替换当前的客户端
-	v.client = client
-
-	// 构建authorize URL
-	authURL := "https://auth.riotgames.com/authorize?redirect_uri=https%3A%2F%2Fplayvalorant.com%2Fopt_in&client_id=play-valorant-web-prod&response_type=token%20id_token&scope=account%20openid&nonce=1"
+	return rc.authenticateWithCookies(filteredCookies)
+}
 
+// authenticateWithCookies 在当前会话客户端上执行Cookie认证流程
+func (rc *RiotClient) authenticateWithCookies(cookies map[string]string) (*models.UserSession, error) {
 	// 创建请求
-	req, err := http.NewRequest(http.MethodGet, authURL, nil)
+	req, err := http.NewRequest(http.MethodGet, rc.api.endpoints.authorizeURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("创建请求失败: %w", err)
 	}
 
 	// 添加请求头
-	v.setRiotRequestHeaders(req, filteredCookies)
+	rc.setRiotRequestHeaders(req, cookies)
 
 	// 发送请求
-	resp, err := v.client.Do(req)
+	resp, err := rc.client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("发送请求失败: %w", err)
 	}
 	defer resp.Body.Close()
 
+	// 记录响应中轮换的Cookie（例如ssid、clid），以便后续重新认证
+	cookies = mergeRotatedCookies(cookies, resp.Cookies())
+
 	// 检查状态码
 	if resp.StatusCode != http.StatusFound && resp.StatusCode != http.StatusSeeOther {
 		bodyBytes, _ := io.ReadAll(resp.Body)
@@ -308,47 +325,62 @@ func (v *ValorantAPI) AuthenticateWithCookies(cookies map[string]string) (*model
 	}
 
 	if strings.Contains(location, "/login") {
-		return nil, errors.New("Cookie无效或已过期")
+		return nil, ErrCookiesExpired
 	}
 
-	if !strings.Contains(location, "access_token=") {
+	return rc.completeAuth(location, cookies)
+}
+
+// completeAuth 根据授权结果中的URI换取授权令牌和用户信息，创建用户会话
+func (rc *RiotClient) completeAuth(uri string, cookies map[string]string) (*models.UserSession, error) {
+	if !strings.Contains(uri, "access_token=") {
 		return nil, errors.New("无法从响应中提取令牌")
 	}
 
-	// 从Location URL中提取访问令牌
-	accessToken, err := parseAccessTokenFromURI(location)
+	// 从URI中提取访问令牌
+	accessToken, err := parseAccessTokenFromURI(uri)
 	if err != nil {
 		return nil, fmt.Errorf("提取访问令牌失败: %w", err)
 	}
 
 	// 获取授权令牌
-	entitlementToken, err := v.getEntitlementToken(accessToken)
+	entitlementToken, err := rc.getEntitlementToken(accessToken)
 	if err != nil {
 		return nil, fmt.Errorf("获取授权令牌失败: %w", err)
 	}
 
 	// 获取用户信息
-	userInfo, err := v.getUserInfo(accessToken)
+	userInfo, err := rc.getUserInfo(accessToken)
 	if err != nil {
 		return nil, fmt.Errorf("获取用户信息失败: %w", err)
 	}
 
+	// 访问令牌约一小时后过期，记录过期时间以便提前重新认证
+	tokenLifetime := parseTokenLifetimeFromURI(uri)
+
+	rc.puuid = userInfo.Sub
+	rc.accessToken = accessToken
+	rc.entitlement = entitlementToken
+
 	// 创建用户会话
 	session := &models.UserSession{
-		UserID:       userInfo.Sub,
-		Username:     userInfo.Email,
-		AccessToken:  accessToken,
-		Entitlement:  entitlementToken,
-		RiotUsername: userInfo.Acct.GameName,
-		RiotTagline:  userInfo.Acct.TagLine,
-		Cookies:      filteredCookies,
+		UserID:         userInfo.Sub,
+		Username:       userInfo.Email,
+		AccessToken:    accessToken,
+		Entitlement:    entitlementToken,
+		RiotUsername:   userInfo.Acct.GameName,
+		RiotTagline:    userInfo.Acct.TagLine,
+		Region:         rc.region,
+		Shard:          rc.shard,
+		Cookies:        cookies,
+		TokenExpiresAt: time.Now().Add(tokenLifetime),
 	}
 
 	return session, nil
 }
 
 // 设置Riot请求头
-func (v *ValorantAPI) setRiotRequestHeaders(req *http.Request, cookies map[string]string) {
+func (rc *RiotClient) setRiotRequestHeaders(req *http.Request, cookies map[string]string) {
 	// 设置常规请求头
 	req.Header.Set("User-Agent", "RiotClient/62.0.1.4852791.4789131 rso-auth (Windows;10;;Professional, x64)")
 	req.Header.Set("Accept", "application/json, text/plain, */*")
@@ -357,7 +389,7 @@ func (v *ValorantAPI) setRiotRequestHeaders(req *http.Request, cookies map[strin
 	req.Header.Set("Origin", "https://auth.riotgames.com")
 	req.Header.Set("DNT", "1")
 	req.Header.Set("Connection", "keep-alive")
-	req.Header.Set("X-Riot-ClientVersion", v.clientVersion) // 添加客户端版本
+	req.Header.Set("X-Riot-ClientVersion", rc.api.clientVersion) // 添加客户端版本
 
 	// 添加Cookie
 	var cookieStrings []string
@@ -386,9 +418,52 @@ func parseAccessTokenFromURI(uri string) (string, error) {
 	return uri[startIndex : startIndex+endIndex], nil
 }
 
+// parseTokenLifetimeFromURI 从URI中提取访问令牌的有效期
+func parseTokenLifetimeFromURI(uri string) time.Duration {
+	startIndex := strings.Index(uri, "expires_in=")
+	if startIndex == -1 {
+		return defaultTokenLifetime
+	}
+	startIndex += len("expires_in=")
+
+	value := uri[startIndex:]
+	if endIndex := strings.Index(value, "&"); endIndex != -1 {
+		value = value[:endIndex]
+	}
+
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds <= 0 {
+		return defaultTokenLifetime
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// mergeRotatedCookies 将Set-Cookie中轮换的关键Cookie合并到现有Cookie中
+func mergeRotatedCookies(cookies map[string]string, rotated []*http.Cookie) map[string]string {
+	merged := make(map[string]string, len(cookies))
+	for name, value := range cookies {
+		merged[name] = value
+	}
+
+	for _, cookie := range rotated {
+		if cookie.Value == "" {
+			continue
+		}
+		for _, name := range essentialCookieNames {
+			if cookie.Name == name {
+				merged[name] = cookie.Value
+				break
+			}
+		}
+	}
+
+	return merged
+}
+
 // 获取授权令牌
-func (v *ValorantAPI) getEntitlementToken(accessToken string) (string, error) {
-	req, err := http.NewRequest(http.MethodPost, entitlementsURL, nil)
+func (rc *RiotClient) getEntitlementToken(accessToken string) (string, error) {
+	req, err := http.NewRequest(http.MethodPost, rc.api.endpoints.entitlementsURL, nil)
 	if err != nil {
 		return "", err
 	}
@@ -396,10 +471,10 @@ func (v *ValorantAPI) getEntitlementToken(accessToken string) (string, error) {
 	// 设置授权头
 	req.Header.Set("Authorization", "Bearer "+accessToken)
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-Riot-ClientVersion", v.clientVersion) // 添加客户端版本
+	req.Header.Set("X-Riot-ClientVersion", rc.api.clientVersion) // 添加客户端版本
 
 	// 发送请求
-	resp, err := v.client.Do(req)
+	resp, err := rc.client.Do(req)
 	if err != nil {
 		return "", err
 	}
@@ -421,18 +496,18 @@ func (v *ValorantAPI) getEntitlementToken(accessToken string) (string, error) {
 }
 
 // 获取用户信息
-func (v *ValorantAPI) getUserInfo(accessToken string) (*models.ValorantUserInfoResponse, error) {
-	req, err := http.NewRequest(http.MethodGet, userInfoURL, nil)
+func (rc *RiotClient) getUserInfo(accessToken string) (*models.ValorantUserInfoResponse, error) {
+	req, err := http.NewRequest(http.MethodGet, rc.api.endpoints.userInfoURL, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	// 设置授权头
 	req.Header.Set("Authorization", "Bearer "+accessToken)
-	req.Header.Set("X-Riot-ClientVersion", v.clientVersion) // 添加客户端版本
+	req.Header.Set("X-Riot-ClientVersion", rc.api.clientVersion) // 添加客户端版本
 
 	// 发送请求
-	resp, err := v.client.Do(req)
+	resp, err := rc.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,160 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/emper0r/val-store-server/internal/models"
+	"github.com/emper0r/val-store-server/internal/repositories/riottest"
+)
+
+// newTestValorantAPI 创建指向本地服务器的ValorantAPI
+func newTestValorantAPI(server *httptest.Server) *ValorantAPI {
+	return NewValorantAPIWithBaseURL(server.URL, "release-test")
+}
+
+func TestResolveRegion(t *testing.T) {
+	tests := []struct {
+		input, region, shard string
+	}{
+		{"", "ap", "ap"},
+		{"AP", "ap", "ap"},
+		{"kr", "kr", "kr"},
+		{"eu", "eu", "eu"},
+		{"na", "na", "na"},
+		{"latam", "latam", "na"},
+		{"br", "br", "na"},
+		{"unknown", "ap", "ap"},
+	}
+
+	for _, tt := range tests {
+		region, shard := ResolveRegion(tt.input)
+		if region != tt.region || shard != tt.shard {
+			t.Errorf("ResolveRegion(%q) = %q, %q; want %q, %q", tt.input, region, shard, tt.region, tt.shard)
+		}
+	}
+}
+
+func TestAuthenticateWithCookiesConcurrent(t *testing.T) {
+	server := riottest.NewServer(t)
+	api := newTestValorantAPI(server)
+	regions := []string{"ap", "na", "eu", "kr", "latam", "br"}
+
+	const logins = 32
+	var wg sync.WaitGroup
+	errs := make(chan error, logins)
+
+	for i := 0; i < logins; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			ssid := fmt.Sprintf("user%d", i)
+			region := regions[i%len(regions)]
+			session, err := api.AuthenticateWithCookies(map[string]string{"ssid": ssid}, region)
+			if err != nil {
+				errs <- fmt.Errorf("登录 %s 失败: %w", ssid, err)
+				return
+			}
+
+			wantRegion, wantShard := ResolveRegion(region)
+			if session.UserID != "puuid-"+ssid ||
+				session.AccessToken != "at-"+ssid ||
+				session.Entitlement != "ent-"+ssid ||
+				session.Region != wantRegion ||
+				session.Shard != wantShard ||
+				session.Cookies["ssid"] != ssid {
+				errs <- fmt.Errorf("登录 %s 得到了错误的会话: %+v", ssid, session)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestAuthenticateWithCookiesExpired(t *testing.T) {
+	server := riottest.NewServer(t)
+	api := newTestValorantAPI(server)
+
+	if _, err := api.AuthenticateWithCookies(map[string]string{"csid": "x"}, "ap"); !errors.Is(err, ErrCookiesExpired) {
+		t.Fatalf("缺少ssid时应当返回ErrCookiesExpired，实际为: %v", err)
+	}
+}
+
+func TestAuthenticateWithCookiesCapturesRotatedCookies(t *testing.T) {
+	server := riottest.NewServer(t)
+	api := newTestValorantAPI(server)
+
+	session, err := api.AuthenticateWithCookies(map[string]string{"ssid": "rotate", "csid": "c"}, "eu")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if session.Cookies["ssid"] != "rotated-ssid" || session.Cookies["clid"] != "rotated-clid" || session.Cookies["csid"] != "c" {
+		t.Errorf("没有正确合并轮换的Cookie: %v", session.Cookies)
+	}
+	if lifetime := time.Until(session.TokenExpiresAt); lifetime < 59*time.Minute || lifetime > time.Hour {
+		t.Errorf("令牌过期时间不正确: %v", lifetime)
+	}
+}
+
+func TestNewClientUsesSessionState(t *testing.T) {
+	server := riottest.NewServer(t)
+	api := newTestValorantAPI(server)
+
+	const clients = 16
+	var wg sync.WaitGroup
+	errs := make(chan error, clients)
+
+	for i := 0; i < clients; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			session := &models.UserSession{
+				UserID:      fmt.Sprintf("puuid-%d", i),
+				AccessToken: fmt.Sprintf("at-%d", i),
+				Entitlement: fmt.Sprintf("ent-%d", i),
+				Region:      []string{"eu", "br"}[i%2],
+			}
+			rc, err := api.NewClient(session)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if rc.client.Transport != api.transport {
+				errs <- fmt.Errorf("客户端 %d 没有复用共享的Transport", i)
+				return
+			}
+
+			var got map[string]string
+			if err := rc.doJSON(http.MethodGet, rc.pdURL("/echo"), nil, &got); err != nil {
+				errs <- err
+				return
+			}
+
+			wantPath := "/pd/" + rc.Shard() + "/echo"
+			if got["path"] != wantPath ||
+				got["auth"] != "Bearer "+session.AccessToken ||
+				got["entitlement"] != session.Entitlement ||
+				got["version"] != "release-test" {
+				errs <- fmt.Errorf("客户端 %d 的请求头或分片错误: %v", i, got)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/emper0r/val-store-server/internal/models"
+	"github.com/emper0r/val-store-server/internal/repositories"
+)
+
+// AccountService 处理玩家账号等级、段位等信息
+type AccountService struct {
+	valorantAPI    *repositories.ValorantAPI
+	contentCatalog *repositories.ContentCatalog
+}
+
+// NewAccountService 创建新的账号服务
+func NewAccountService(valorantAPI *repositories.ValorantAPI, contentCatalog *repositories.ContentCatalog) *AccountService {
+	return &AccountService{
+		valorantAPI:    valorantAPI,
+		contentCatalog: contentCatalog,
+	}
+}
+
+// GetAccountXP 获取账号等级、当前等级的经验进度和最近的经验来源
+func (s *AccountService) GetAccountXP(session *models.UserSession, language string) (*models.AccountXPResponse, error) {
+	client, err := s.valorantAPI.NewClient(session)
+	if err != nil {
+		return nil, err
+	}
+
+	accountXP, err := client.GetAccountXP()
+	if err != nil {
+		return nil, err
+	}
+
+	return newAccountXP(accountXP, loadCatalog(s.contentCatalog, language)), nil
+}
+
+// newAccountXP 计算当前等级的经验进度，并整理最近的经验来源
+func newAccountXP(accountXP *models.ValorantAccountXPResponse, catalog *repositories.Catalog) *models.AccountXPResponse {
+	progress := accountXP.Progress
+	response := &models.AccountXPResponse{
+		Level:                 progress.Level,
+		XP:                    progress.XP,
+		XPPerLevel:            models.XPPerLevel,
+		XPToNextLevel:         models.XPPerLevel - progress.XP,
+		Progress:              float64(progress.XP) / models.XPPerLevel,
+		History:               make([]models.XPHistoryEntry, 0, len(accountXP.History)),
+		LastFirstWin:          accountXP.LastTimeGrantedFirstWin,
+		NextFirstWinAvailable: accountXP.NextTimeFirstWinAvailable,
+	}
+	// 升级结算前Riot可能返回超过一级的经验
+	if response.XPToNextLevel < 0 {
+		response.XPToNextLevel = 0
+		response.Progress = 1
+	}
+
+	if border := catalog.LevelBorder(progress.Level); border != nil {
+		response.LevelBorder = &models.LevelBorderInfo{
+			UUID:          border.UUID,
+			StartingLevel: border.StartingLevel,
+			LevelIcon:     border.LevelNumberAppearance,
+			CardIcon:      border.SmallPlayerCardAppearance,
+		}
+	}
+
+	for _, match := range accountXP.History {
+		entry := models.XPHistoryEntry{
+			MatchID:    match.ID,
+			MatchStart: match.MatchStart,
+			StartLevel: match.StartProgress.Level,
+			EndLevel:   match.EndProgress.Level,
+			XPDelta:    match.XPDelta,
+			Sources:    make([]models.XPSource, 0, len(match.XPSources)),
+		}
+		for _, source := range match.XPSources {
+			name, ok := models.XPSourceNames[source.ID]
+			if !ok {
+				name = source.ID
+			}
+			entry.Sources = append(entry.Sources, models.XPSource{
+				ID:     source.ID,
+				Name:   name,
+				Amount: source.Amount,
+			})
+		}
+		response.History = append(response.History, entry)
+	}
+
+	// 最近的比赛排在前面
+	sort.SliceStable(response.History, func(i, j int) bool {
+		return response.History[i].MatchStart.After(response.History[j].MatchStart)
+	})
+
+	return response
+}
+
+// competitiveQueue 竞技模式在QueueSkills中的键
+const competitiveQueue = "competitive"
+
+// GetMMR 获取当前段位、排名分、排行榜名次和各赛季的竞技模式战绩
+func (s *AccountService) GetMMR(session *models.UserSession, language string) (*models.MMRResponse, error) {
+	client, err := s.valorantAPI.NewClient(session)
+	if err != nil {
+		return nil, err
+	}
+
+	mmr, err := client.GetMMR()
+	if err != nil {
+		return nil, err
+	}
+
+	return newMMR(mmr, loadCatalog(s.contentCatalog, language), time.Now()), nil
+}
+
+// newMMR 将Riot的MMR数据映射为段位和各赛季战绩
+func newMMR(mmr *models.ValorantMMRResponse, catalog *repositories.Catalog, now time.Time) *models.MMRResponse {
+	competitive := mmr.QueueSkills[competitiveQueue]
+	latest := mmr.LatestCompetitiveUpdate
+
+	// 优先使用内容目录中的当前小赛季，目录不可用时使用最近一场竞技比赛的赛季
+	currentSeasonID := latest.SeasonID
+	if act := catalog.CurrentAct(now); act != nil {
+		currentSeasonID = act.UUID
+	}
+
+	response := &models.MMRResponse{
+		CurrentSeasonID:      currentSeasonID,
+		CurrentSeasonName:    catalog.SeasonName(currentSeasonID),
+		Rank:                 catalog.ResolveRank(currentSeasonID, 0),
+		GamesNeededForRating: competitive.TotalGamesNeededForRating,
+		Seasons:              make([]models.SeasonMMR, 0, len(competitive.SeasonalInfoBySeasonID)),
+	}
+
+	for seasonID, info := range competitive.SeasonalInfoBySeasonID {
+		season := models.SeasonMMR{
+			SeasonID:        seasonID,
+			SeasonName:      catalog.SeasonName(seasonID),
+			Rank:            catalog.ResolveRank(seasonID, info.CompetitiveTier),
+			RankedRating:    info.RankedRating,
+			LeaderboardRank: info.LeaderboardRank,
+			Wins:            info.NumberOfWinsWithPlacements,
+			Games:           info.NumberOfGames,
+		}
+		response.Seasons = append(response.Seasons, season)
+
+		if strings.EqualFold(seasonID, currentSeasonID) {
+			response.Rank = season.Rank
+			response.RankedRating = season.RankedRating
+			response.LeaderboardRank = season.LeaderboardRank
+			response.GamesNeededForRating = info.GamesNeededForRating
+		}
+	}
+
+	// 赛季开始时间未知时按ID排序，保证结果稳定
+	sort.Slice(response.Seasons, func(i, j int) bool {
+		a, b := catalog.SeasonStart(response.Seasons[i].SeasonID), catalog.SeasonStart(response.Seasons[j].SeasonID)
+		if !a.Equal(b) {
+			return a.After(b)
+		}
+		return response.Seasons[i].SeasonID < response.Seasons[j].SeasonID
+	})
+
+	if latest.MatchID != "" {
+		response.LatestUpdate = &models.LatestRankedUpdate{
+			MatchID:            latest.MatchID,
+			MatchStart:         time.UnixMilli(latest.MatchStartTime).UTC(),
+			RankBefore:         catalog.ResolveRank(latest.SeasonID, latest.TierBeforeUpdate),
+			RankAfter:          catalog.ResolveRank(latest.SeasonID, latest.TierAfterUpdate),
+			RankedRatingEarned: latest.RankedRatingEarned,
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/emper0r/val-store-server/internal/models"
+	"github.com/emper0r/val-store-server/internal/repositories"
+)
+
+// testAccountXPJSON 两场比赛的经验记录，较早的比赛排在前面
+const testAccountXPJSON = `{
+	"History": [
+		{"ID": "match-old", "MatchStart": "2024-01-01T00:00:00Z", "StartProgress": {"Level": 19}, "EndProgress": {"Level": 20}, "XPDelta": 4000,
+			"XPSources": [{"ID": "time-played", "Amount": 1000}, {"ID": "match-win", "Amount": 2000}, {"ID": "first-win-of-the-day", "Amount": 1000}]},
+		{"ID": "match-new", "MatchStart": "2024-01-02T00:00:00Z", "StartProgress": {"Level": 20}, "EndProgress": {"Level": 20}, "XPDelta": 1200,
+			"XPSources": [{"ID": "time-played", "Amount": 1200}, {"ID": "new-source", "Amount": 0}]}
+	]
+}`
+
+func TestNewAccountXP(t *testing.T) {
+	catalog := &repositories.Catalog{
+		LevelBorders: []*models.ContentLevelBorder{
+			{UUID: "border-1", StartingLevel: 1},
+			{UUID: "border-20", StartingLevel: 20, LevelNumberAppearance: "level.png", SmallPlayerCardAppearance: "card.png"},
+			{UUID: "border-40", StartingLevel: 40},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		level, xp         int
+		catalog           *repositories.Catalog
+		wantXPToNextLevel int
+		wantProgress      float64
+		wantBorder        string
+	}{
+		{name: "等级中途", level: 20, xp: 1250, catalog: catalog, wantXPToNextLevel: 3750, wantProgress: 0.25, wantBorder: "border-20"},
+		{name: "刚升级", level: 39, xp: 0, catalog: catalog, wantXPToNextLevel: 5000, wantProgress: 0, wantBorder: "border-20"},
+		{name: "经验超过一级时截断", level: 40, xp: 5200, catalog: catalog, wantXPToNextLevel: 0, wantProgress: 1, wantBorder: "border-40"},
+		{name: "目录未加载", level: 20, xp: 2500, wantXPToNextLevel: 2500, wantProgress: 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var accountXP models.ValorantAccountXPResponse
+			if err := json.Unmarshal([]byte(testAccountXPJSON), &accountXP); err != nil {
+				t.Fatal(err)
+			}
+			accountXP.Progress = models.ValorantAccountProgress{Level: tt.level, XP: tt.xp}
+
+			response := newAccountXP(&accountXP, tt.catalog)
+
+			if response.Level != tt.level || response.XP != tt.xp || response.XPPerLevel != models.XPPerLevel ||
+				response.XPToNextLevel != tt.wantXPToNextLevel || response.Progress != tt.wantProgress {
+				t.Errorf("等级进度不正确: %+v", response)
+			}
+			border := ""
+			if response.LevelBorder != nil {
+				border = response.LevelBorder.UUID
+			}
+			if border != tt.wantBorder {
+				t.Errorf("等级边框为%q，期望%q", border, tt.wantBorder)
+			}
+		})
+	}
+}
+
+func TestNewAccountXPHistory(t *testing.T) {
+	var accountXP models.ValorantAccountXPResponse
+	if err := json.Unmarshal([]byte(testAccountXPJSON), &accountXP); err != nil {
+		t.Fatal(err)
+	}
+
+	response := newAccountXP(&accountXP, nil)
+
+	// 最近的比赛排在前面，未知的经验来源使用ID作为名称
+	got := make([]string, 0, len(response.History))
+	for _, entry := range response.History {
+		got = append(got, fmt.Sprintf("%s %d->%d %d %v", entry.MatchID, entry.StartLevel, entry.EndLevel, entry.XPDelta, entry.Sources))
+	}
+	want := []string{
+		"match-new 20->20 1200 [{time-played 游戏时长 1200} {new-source new-source 0}]",
+		"match-old 19->20 4000 [{time-played 游戏时长 1000} {match-win 比赛胜利 2000} {first-win-of-the-day 每日首胜 1000}]",
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("经验记录为%q，期望%q", got, want)
+	}
+}
+
+// testMMRJSON 两个小赛季的竞技战绩，最近一场比赛在act-2
+const testMMRJSON = `{
+	"QueueSkills": {
+		"competitive": {
+			"TotalGamesNeededForRating": 5,
+			"SeasonalInfoBySeasonID": {
+				"act-1": {"SeasonID": "act-1", "CompetitiveTier": 21, "RankedRating": 50, "NumberOfWinsWithPlacements": 10, "NumberOfGames": 20},
+				"act-2": {"SeasonID": "act-2", "CompetitiveTier": 24, "RankedRating": 30, "LeaderboardRank": 500, "NumberOfWinsWithPlacements": 3, "NumberOfGames": 4, "GamesNeededForRating": 1}
+			}
+		},
+		"unrated": {"SeasonalInfoBySeasonID": {"act-2": {"SeasonID": "act-2", "CompetitiveTier": 0, "NumberOfGames": 9}}}
+	},
+	"LatestCompetitiveUpdate": {"MatchID": "match-1", "SeasonID": "act-2", "MatchStartTime": 1710000000000, "TierBeforeUpdate": 23, "TierAfterUpdate": 24, "RankedRatingEarned": 25}
+}`
+
+// newMMRTestCatalog 创建包含三个小赛季和一个段位组的目录
+func newMMRTestCatalog() *repositories.Catalog {
+	day := func(month time.Month, d int) time.Time { return time.Date(2024, month, d, 0, 0, 0, 0, time.UTC) }
+	tiers := &models.ContentCompetitiveTierSet{
+		UUID: "tiers",
+		Tiers: []models.ContentCompetitiveTier{
+			{Tier: 0, TierName: "UNRANKED"},
+			{Tier: 21, TierName: "ASCENDANT 1", DivisionName: "ASCENDANT", Color: "2f9e6bff"},
+			{Tier: 23, TierName: "ASCENDANT 3", DivisionName: "ASCENDANT", Color: "2f9e6bff"},
+			{Tier: 24, TierName: "IMMORTAL 1", DivisionName: "IMMORTAL", Color: "bb3d65ff"},
+		},
+	}
+	return &repositories.Catalog{
+		Seasons: map[string]*models.ContentSeason{
+			"episode-8": {UUID: "episode-8", DisplayName: "EPISODE 8"},
+			"act-1":     {UUID: "act-1", DisplayName: "ACT I", Type: models.SeasonTypeAct, ParentUUID: "episode-8", StartTime: day(1, 10), EndTime: day(3, 1)},
+			"act-2":     {UUID: "act-2", DisplayName: "ACT II", Type: models.SeasonTypeAct, ParentUUID: "episode-8", StartTime: day(3, 1), EndTime: day(5, 1)},
+			"act-3":     {UUID: "act-3", DisplayName: "ACT III", Type: models.SeasonTypeAct, ParentUUID: "episode-8", StartTime: day(5, 1), EndTime: day(7, 1)},
+		},
+		SeasonTiers:      map[string]string{"act-1": "tiers", "act-2": "tiers", "act-3": "tiers"},
+		CompetitiveTiers: map[string]*models.ContentCompetitiveTierSet{"tiers": tiers},
+	}
+}
+
+func TestNewMMR(t *testing.T) {
+	catalog := newMMRTestCatalog()
+
+	tests := []struct {
+		name                 string
+		catalog              *repositories.Catalog
+		now                  time.Time
+		wantSeason           string
+		wantSeasonName       string
+		wantRank             string
+		wantTier             int
+		wantRR               int
+		wantLeaderboard      int
+		wantGamesNeeded      int
+		wantSeasons          []string
+		wantLatestRankBefore string
+	}{
+		{
+			name:    "当前小赛季有战绩",
+			catalog: catalog, now: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+			wantSeason: "act-2", wantSeasonName: "EPISODE 8 ACT II", wantRank: "IMMORTAL 1", wantTier: 24,
+			wantRR: 30, wantLeaderboard: 500, wantGamesNeeded: 1,
+			wantSeasons:          []string{"act-2 IMMORTAL 1 30 3/4", "act-1 ASCENDANT 1 50 10/20"},
+			wantLatestRankBefore: "ASCENDANT 3",
+		},
+		{
+			name:    "新的小赛季还没有比赛",
+			catalog: catalog, now: time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC),
+			wantSeason: "act-3", wantSeasonName: "EPISODE 8 ACT III", wantRank: "UNRANKED", wantTier: 0,
+			wantGamesNeeded:      5,
+			wantSeasons:          []string{"act-2 IMMORTAL 1 30 3/4", "act-1 ASCENDANT 1 50 10/20"},
+			wantLatestRankBefore: "ASCENDANT 3",
+		},
+		{
+			name:       "目录未加载时使用最近一场比赛的赛季",
+			now:        time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+			wantSeason: "act-2", wantTier: 24, wantRR: 30, wantLeaderboard: 500, wantGamesNeeded: 1,
+			wantSeasons: []string{"act-1  50 10/20", "act-2  30 3/4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mmr models.ValorantMMRResponse
+			if err := json.Unmarshal([]byte(testMMRJSON), &mmr); err != nil {
+				t.Fatal(err)
+			}
+
+			response := newMMR(&mmr, tt.catalog, tt.now)
+
+			if response.CurrentSeasonID != tt.wantSeason || response.CurrentSeasonName != tt.wantSeasonName {
+				t.Errorf("当前赛季为%q(%q)，期望%q(%q)", response.CurrentSeasonID, response.CurrentSeasonName, tt.wantSeason, tt.wantSeasonName)
+			}
+			if response.Rank.Name != tt.wantRank || response.Rank.Tier != tt.wantTier || response.RankedRating != tt.wantRR ||
+				response.LeaderboardRank != tt.wantLeaderboard || response.GamesNeededForRating != tt.wantGamesNeeded {
+				t.Errorf("当前段位不正确: %+v", response)
+			}
+
+			seasons := make([]string, 0, len(response.Seasons))
+			for _, season := range response.Seasons {
+				seasons = append(seasons, fmt.Sprintf("%s %s %d %d/%d", season.SeasonID, season.Rank.Name, season.RankedRating, season.Wins, season.Games))
+			}
+			if fmt.Sprint(seasons) != fmt.Sprint(tt.wantSeasons) {
+				t.Errorf("赛季战绩为%q，期望%q", seasons, tt.wantSeasons)
+			}
+
+			latest := response.LatestUpdate
+			if latest == nil || latest.MatchID != "match-1" || latest.RankAfter.Tier != 24 || latest.RankBefore.Name != tt.wantLatestRankBefore ||
+				latest.RankedRatingEarned != 25 || !latest.MatchStart.Equal(time.UnixMilli(1710000000000)) {
+				t.Errorf("最近的排名分变化不正确: %+v", latest)
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package services
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"time"
 
@@ -12,22 +15,27 @@ import (
 
 // AuthService 处理认证相关的业务逻辑
 type AuthService struct {
-	valorantAPI *repositories.ValorantAPI
-	jwtSecret   string
-	tokenExpiry time.Duration
+	valorantAPI  *repositories.ValorantAPI
+	sessionStore repositories.SessionStore
+	jwtSecret    string
+	tokenExpiry  time.Duration
+	refreshLocks sessionLocks
+	logins       *pendingLogins
 }
 
 // NewAuthService 创建新的认证服务
-func NewAuthService(valorantAPI *repositories.ValorantAPI) *AuthService {
+func NewAuthService(valorantAPI *repositories.ValorantAPI, sessionStore repositories.SessionStore) *AuthService {
 	// 从环境变量获取JWT密钥，默认为一个随机字符串（仅用于开发环境）
 	jwtSecret := config.GetEnv("JWT_SECRET", "val-store-server-secret-key-development-only")
 	// JWT令牌有效期，默认24小时
 	tokenExpiry := 24 * time.Hour
 
 	return &AuthService{
-		valorantAPI: valorantAPI,
-		jwtSecret:   jwtSecret,
-		tokenExpiry: tokenExpiry,
+		valorantAPI:  valorantAPI,
+		sessionStore: sessionStore,
+		jwtSecret:    jwtSecret,
+		tokenExpiry:  tokenExpiry,
+		logins:       newPendingLogins(pendingLoginTTL, maxPendingLogins),
 	}
 }
 
@@ -41,27 +49,31 @@ func (s *AuthService) LoginWithCookies(cookieStr string, region string) (*models
 		return nil, fmt.Errorf("无法解析Cookie字符串，请确保格式正确")
 	}
 
-	// 如果提供了区域，则设置
-	if region != "" {
-		s.valorantAPI.SetRegion(region)
+	// 调用认证方法，区域只作用于本次登录的会话客户端
+	session, err := s.valorantAPI.AuthenticateWithCookies(cookies, region)
+	if err != nil {
+		return nil, fmt.Errorf("Cookie认证失败: %w", err)
 	}
 
-	// 调用认证方法
-	session, err := s.valorantAPI.AuthenticateWithCookies(cookies)
+	return s.createSession(session)
+}
+
+// createSession 保存登录得到的Riot会话，并签发对应的JWT令牌
+func (s *AuthService) createSession(session *models.UserSession) (*models.UserTokensResponse, error) {
+	// 生成会话ID，作为JWT的jti
+	sessionID, err := newSessionID()
 	if err != nil {
-		return nil, fmt.Errorf("Cookie认证失败: %w", err)
+		return nil, fmt.Errorf("生成会话ID失败: %w", err)
 	}
+	session.ExpiresAt = time.Now().Add(s.tokenExpiry)
 
-	// 设置区域
-	if region != "" {
-		session.Region = region
-	} else {
-		// 如果未提供区域，使用默认值AP
-		session.Region = models.RegionAP
+	// 保存Riot会话，供后续接口代表用户调用Riot服务
+	if err := s.sessionStore.Save(sessionID, session); err != nil {
+		return nil, fmt.Errorf("保存会话失败: %w", err)
 	}
 
 	// 生成JWT令牌
-	token, err := s.generateJWT(session)
+	token, err := s.generateJWT(session, sessionID)
 	if err != nil {
 		return nil, fmt.Errorf("生成JWT失败: %w", err)
 	}
@@ -88,7 +100,7 @@ func (s *AuthService) LoginWithCookies(cookieStr string, region string) (*models
 }
 
 // generateJWT 生成JWT令牌
-func (s *AuthService) generateJWT(session *models.UserSession) (string, error) {
+func (s *AuthService) generateJWT(session *models.UserSession, sessionID string) (string, error) {
 	// 构建格式化的用户名
 	formattedUsername := session.RiotUsername
 	if session.RiotTagline != "" {
@@ -100,7 +112,8 @@ func (s *AuthService) generateJWT(session *models.UserSession) (string, error) {
 		UserID:   session.UserID,
 		Username: formattedUsername,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.tokenExpiry)),
+			ID:        sessionID,
+			ExpiresAt: jwt.NewNumericDate(session.ExpiresAt),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
@@ -139,3 +152,46 @@ func (s *AuthService) ValidateToken(tokenString string) (*models.JWTClaims, erro
 
 	return nil, fmt.Errorf("无效的令牌")
 }
+
+// LoadSession 根据JWT声明中的jti加载对应的Riot会话
+func (s *AuthService) LoadSession(claims *models.JWTClaims) (*models.UserSession, error) {
+	if claims.ID == "" {
+		return nil, errors.New("令牌中缺少会话ID")
+	}
+
+	session, err := s.sessionStore.Get(claims.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 防止令牌与会话不匹配
+	if session.UserID != claims.UserID {
+		return nil, errors.New("令牌与会话不匹配")
+	}
+
+	if session.Dead {
+		return nil, ErrSessionDead
+	}
+
+	// 后台任务没来得及刷新时，在请求中同步重新认证
+	if needsRefresh(session, time.Now()) {
+		refreshed, err := s.RefreshSession(claims.ID)
+		if err == nil {
+			return refreshed, nil
+		}
+		if errors.Is(err, ErrSessionDead) || time.Now().After(session.TokenExpiresAt) {
+			return nil, err
+		}
+	}
+
+	return session, nil
+}
+
+// newSessionID 生成随机的会话ID
+func newSessionID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,18 @@
+package services
+
+import (
+	"log"
+
+	"github.com/emper0r/val-store-server/internal/repositories"
+)
+
+// loadCatalog 加载指定语言的内容目录，失败时记录日志并返回nil
+// Catalog的解析方法允许nil接收者，此时接口仍会返回未解析名称的原始数据
+func loadCatalog(contentCatalog *repositories.ContentCatalog, language string) *repositories.Catalog {
+	catalog, err := contentCatalog.Get(language)
+	if err != nil {
+		log.Printf("警告: 加载游戏内容失败: %v", err)
+		return nil
+	}
+	return catalog
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/emper0r/val-store-server/internal/models"
+	"github.com/emper0r/val-store-server/internal/repositories"
+)
+
+// ErrNoBattlePass 当前没有进行中的小赛季或找不到对应的通行证
+var ErrNoBattlePass = errors.New("当前没有进行中的通行证")
+
+// defaultXPPerMatch 没有历史数据时各模式每场比赛的估计经验
+var defaultXPPerMatch = map[string]int{
+	"competitive": 4000,
+	"unrated":     4000,
+	"swiftplay":   1500,
+	"spikerush":   1000,
+	"deathmatch":  900,
+}
+
+// projectionHistorySize 计算平均经验时使用的最近比赛数
+const projectionHistorySize = 20
+
+// GetContractProjection 预测完成当前通行证每天需要的经验和各模式的比赛数
+// 小赛季的结束时间来自Riot内容服务；比赛获得的通行证经验与账号经验相同，按账号经验历史计算各模式的平均值
+func (s *ContractService) GetContractProjection(session *models.UserSession, language string) (*models.ContractProjectionResponse, error) {
+	client, err := s.valorantAPI.NewClient(session)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := loadCatalog(s.contentCatalog, language)
+	if catalog == nil {
+		return nil, ErrCatalogUnavailable
+	}
+
+	content, err := client.GetGameContent()
+	if err != nil {
+		return nil, err
+	}
+
+	var actID string
+	var actStart, actEnd time.Time
+	for _, season := range content.Seasons {
+		if season.IsActive && strings.EqualFold(season.Type, "act") {
+			actID = season.ID
+			actStart = season.StartTime
+			actEnd = season.EndTime
+			break
+		}
+	}
+	battlePass := battlePassForAct(catalog, actID)
+	if actID == "" || battlePass == nil {
+		return nil, ErrNoBattlePass
+	}
+
+	contracts, err := client.GetContracts()
+	if err != nil {
+		return nil, err
+	}
+	var state contractState
+	for _, contract := range contracts.Contracts {
+		if strings.EqualFold(contract.ContractDefinitionID, battlePass.UUID) {
+			state = contractState{
+				levelReached: contract.ProgressionLevelReached,
+				towardsNext:  contract.ProgressionTowardsNextLevel,
+				totalEarned:  contract.ContractProgression.TotalProgressionEarned,
+			}
+			break
+		}
+	}
+
+	progress := newContractProgress(battlePass.UUID, state, catalog)
+	queueXP := fetchQueueXP(client)
+
+	response := projectContract(progress, state, actStart, actEnd, queueXP, catalog, time.Now())
+	response.ActID = actID
+	response.ActName = catalog.SeasonName(actID)
+	return response, nil
+}
+
+// projectContract 根据通行证进度、小赛季时间和各模式的经验历史计算预测结果
+// queueXP为各模式最近比赛获得的经验，没有历史数据的模式使用默认估计值
+func projectContract(progress models.ContractProgress, state contractState, actStart, actEnd time.Time, queueXP map[string][]int, catalog *repositories.Catalog, now time.Time) *models.ContractProjectionResponse {
+	response := &models.ContractProjectionResponse{
+		ActEndsAt:  actEnd,
+		Tier:       progress.Tier,
+		TotalTiers: progress.TotalTiers,
+		Complete:   progress.Complete,
+	}
+	for _, tier := range progress.Tiers[min(state.levelReached, len(progress.Tiers)):] {
+		response.RemainingXP += tier.XP
+	}
+	response.RemainingXP = max(response.RemainingXP-state.towardsNext, 0)
+
+	remaining := actEnd.Sub(now)
+	response.DaysRemaining = round(math.Max(remaining.Hours()/24, 0), 1)
+	if remaining > 0 {
+		response.XPPerDayNeeded = int(math.Ceil(float64(response.RemainingXP) / (remaining.Hours() / 24)))
+	} else {
+		response.XPPerDayNeeded = response.RemainingXP
+	}
+
+	// 小赛季刚开始时按至少一天计算，避免平均值被放大
+	elapsedDays := math.Max(now.Sub(actStart).Hours()/24, 1)
+	response.XPPerDayEarned = int(float64(state.totalEarned) / elapsedDays)
+	response.OnTrack = response.Complete || response.XPPerDayEarned >= response.XPPerDayNeeded
+
+	response.Modes = projectModes(response, queueXP, catalog)
+	return response
+}
+
+// fetchQueueXP 按模式整理最近比赛获得的经验，比赛获得的通行证经验与账号经验相同
+// 历史数据获取失败时返回空结果，各模式使用默认估计值
+func fetchQueueXP(client *repositories.RiotClient) map[string][]int {
+	queueXP := make(map[string][]int)
+
+	accountXP, err := client.GetAccountXP()
+	if err != nil {
+		log.Printf("警告: 获取账号经验历史失败，使用默认估计值: %v", err)
+		return queueXP
+	}
+	history, err := client.GetMatchHistory(0, projectionHistorySize, "")
+	if err != nil {
+		log.Printf("警告: 获取比赛历史失败，使用默认估计值: %v", err)
+		return queueXP
+	}
+
+	queues := make(map[string]string, len(history.History))
+	for _, match := range history.History {
+		queues[match.MatchID] = strings.ToLower(match.QueueID)
+	}
+	for _, match := range accountXP.History {
+		queue, ok := queues[match.ID]
+		if !ok || queue == "" || match.XPDelta <= 0 {
+			continue
+		}
+		queueXP[queue] = append(queueXP[queue], match.XPDelta)
+	}
+
+	return queueXP
+}
+
+// projectModes 按各模式的平均经验计算需要的比赛数
+func projectModes(response *models.ContractProjectionResponse, queueXP map[string][]int, catalog *repositories.Catalog) []models.ModeProjection {
+	modes := make([]models.ModeProjection, 0, len(defaultXPPerMatch)+len(queueXP))
+	add := func(queue string, avg, count int) {
+		mode := models.ModeProjection{
+			QueueID:       queue,
+			QueueName:     catalog.QueueName(queue),
+			AvgXPPerMatch: avg,
+			Samples:       count,
+		}
+		if avg > 0 {
+			mode.MatchesPerDay = round(float64(response.XPPerDayNeeded)/float64(avg), 1)
+			mode.MatchesTotal = int(math.Ceil(float64(response.RemainingXP) / float64(avg)))
+		}
+		modes = append(modes, mode)
+	}
+	for queue, xp := range queueXP {
+		if len(xp) == 0 {
+			continue
+		}
+		total := 0
+		for _, delta := range xp {
+			total += delta
+		}
+		add(queue, total/len(xp), len(xp))
+	}
+	for queue, avg := range defaultXPPerMatch {
+		if len(queueXP[queue]) == 0 {
+			add(queue, avg, 0)
+		}
+	}
+
+	sort.Slice(modes, func(i, j int) bool {
+		if modes[i].AvgXPPerMatch != modes[j].AvgXPPerMatch {
+			return modes[i].AvgXPPerMatch > modes[j].AvgXPPerMatch
+		}
+		return modes[i].QueueID < modes[j].QueueID
+	})
+	return modes
+}
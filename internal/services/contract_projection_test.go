@@ -0,0 +1,132 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/emper0r/val-store-server/internal/models"
+)
+
+// newTestProgress 创建三个等级（1000、2000、3000经验）的通行证进度
+func newTestProgress(state contractState) models.ContractProgress {
+	progress := models.ContractProgress{
+		Tier:       state.levelReached,
+		TotalTiers: 3,
+		Complete:   state.levelReached >= 3,
+	}
+	for i, xp := range []int{1000, 2000, 3000} {
+		progress.Tiers = append(progress.Tiers, models.ContractTier{Tier: i + 1, XP: xp})
+	}
+	return progress
+}
+
+func TestProjectContract(t *testing.T) {
+	now := time.Date(2024, 1, 20, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	inProgress := contractState{levelReached: 1, towardsNext: 500, totalEarned: 1500}
+
+	tests := []struct {
+		name               string
+		state              contractState
+		actStart, actEnd   time.Time
+		wantRemainingXP    int
+		wantDaysRemaining  float64
+		wantXPPerDayNeeded int
+		wantXPPerDayEarned int
+		wantOnTrack        bool
+	}{
+		{
+			name:  "进行中且进度落后",
+			state: inProgress, actStart: now.Add(-10 * day), actEnd: now.Add(5 * day),
+			wantRemainingXP: 4500, wantDaysRemaining: 5, wantXPPerDayNeeded: 900, wantXPPerDayEarned: 150,
+		},
+		{
+			name:  "进行中且进度领先",
+			state: contractState{levelReached: 1, towardsNext: 500, totalEarned: 15000}, actStart: now.Add(-10 * day), actEnd: now.Add(5 * day),
+			wantRemainingXP: 4500, wantDaysRemaining: 5, wantXPPerDayNeeded: 900, wantXPPerDayEarned: 1500, wantOnTrack: true,
+		},
+		{
+			name:  "小赛季刚开始时按一天计算每天获得的经验",
+			state: inProgress, actStart: now.Add(-time.Hour), actEnd: now.Add(45 * day),
+			wantRemainingXP: 4500, wantDaysRemaining: 45, wantXPPerDayNeeded: 100, wantXPPerDayEarned: 1500, wantOnTrack: true,
+		},
+		{
+			name:  "小赛季已结束",
+			state: inProgress, actStart: now.Add(-60 * day), actEnd: now.Add(-time.Hour),
+			wantRemainingXP: 4500, wantDaysRemaining: 0, wantXPPerDayNeeded: 4500, wantXPPerDayEarned: 25,
+		},
+		{
+			name:  "通行证已完成",
+			state: contractState{levelReached: 3, totalEarned: 6000}, actStart: now.Add(-10 * day), actEnd: now.Add(5 * day),
+			wantRemainingXP: 0, wantDaysRemaining: 5, wantXPPerDayNeeded: 0, wantXPPerDayEarned: 600, wantOnTrack: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := projectContract(newTestProgress(tt.state), tt.state, tt.actStart, tt.actEnd, nil, nil, now)
+
+			if response.RemainingXP != tt.wantRemainingXP || response.DaysRemaining != tt.wantDaysRemaining ||
+				response.XPPerDayNeeded != tt.wantXPPerDayNeeded || response.XPPerDayEarned != tt.wantXPPerDayEarned ||
+				response.OnTrack != tt.wantOnTrack {
+				t.Errorf("预测结果不正确: %+v", response)
+			}
+			if !response.ActEndsAt.Equal(tt.actEnd) || response.Tier != tt.state.levelReached || response.TotalTiers != 3 {
+				t.Errorf("通行证信息不正确: %+v", response)
+			}
+		})
+	}
+}
+
+func TestProjectModes(t *testing.T) {
+	response := &models.ContractProjectionResponse{RemainingXP: 4500, XPPerDayNeeded: 900}
+	queueXP := map[string][]int{
+		"competitive": {4000, 5000},
+		"newmode":     {2000},
+		"spikerush":   {},
+	}
+
+	modes := projectModes(response, queueXP, nil)
+	byQueue := make(map[string]models.ModeProjection, len(modes))
+	for _, mode := range modes {
+		byQueue[mode.QueueID] = mode
+	}
+
+	tests := []struct {
+		queue             string
+		wantAvg           int
+		wantSamples       int
+		wantMatchesPerDay float64
+		wantMatchesTotal  int
+	}{
+		{queue: "competitive", wantAvg: 4500, wantSamples: 2, wantMatchesPerDay: 0.2, wantMatchesTotal: 1},
+		{queue: "newmode", wantAvg: 2000, wantSamples: 1, wantMatchesPerDay: 0.5, wantMatchesTotal: 3},
+		// 没有历史数据的模式使用默认估计值
+		{queue: "swiftplay", wantAvg: 1500, wantMatchesPerDay: 0.6, wantMatchesTotal: 3},
+		{queue: "spikerush", wantAvg: 1000, wantMatchesPerDay: 0.9, wantMatchesTotal: 5},
+		{queue: "unrated", wantAvg: 4000, wantMatchesPerDay: 0.2, wantMatchesTotal: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.queue, func(t *testing.T) {
+			mode, ok := byQueue[tt.queue]
+			if !ok {
+				t.Fatalf("结果中没有模式%s", tt.queue)
+			}
+			if mode.AvgXPPerMatch != tt.wantAvg || mode.Samples != tt.wantSamples ||
+				mode.MatchesPerDay != tt.wantMatchesPerDay || mode.MatchesTotal != tt.wantMatchesTotal {
+				t.Errorf("模式预测不正确: %+v", mode)
+			}
+		})
+	}
+
+	if len(modes) != len(defaultXPPerMatch)+1 {
+		t.Errorf("模式数量为%d，期望%d", len(modes), len(defaultXPPerMatch)+1)
+	}
+	for i := 1; i < len(modes); i++ {
+		if modes[i-1].AvgXPPerMatch < modes[i].AvgXPPerMatch {
+			t.Errorf("模式没有按平均经验降序排列: %+v", modes)
+			break
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/emper0r/val-store-server/internal/models"
+	"github.com/emper0r/val-store-server/internal/repositories"
+)
+
+// ContractService 处理通行证、特工合约和任务相关的业务逻辑
+type ContractService struct {
+	valorantAPI    *repositories.ValorantAPI
+	contentCatalog *repositories.ContentCatalog
+}
+
+// NewContractService 创建新的合约服务
+func NewContractService(valorantAPI *repositories.ValorantAPI, contentCatalog *repositories.ContentCatalog) *ContractService {
+	return &ContractService{
+		valorantAPI:    valorantAPI,
+		contentCatalog: contentCatalog,
+	}
+}
+
+// contractState 玩家在单个合约上的进度
+type contractState struct {
+	levelReached int
+	towardsNext  int
+	totalEarned  int
+}
+
+// GetContracts 获取当前通行证、激活的合约和所有特工合约的进度
+// 合约的等级和奖励只能从内容目录中获得，目录不可用时返回ErrCatalogUnavailable
+func (s *ContractService) GetContracts(session *models.UserSession, language string) (*models.ContractsResponse, error) {
+	client, err := s.valorantAPI.NewClient(session)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := loadCatalog(s.contentCatalog, language)
+	if catalog == nil {
+		return nil, ErrCatalogUnavailable
+	}
+
+	contracts, err := client.GetContracts()
+	if err != nil {
+		return nil, err
+	}
+
+	states := make(map[string]contractState, len(contracts.Contracts))
+	for _, contract := range contracts.Contracts {
+		states[strings.ToLower(contract.ContractDefinitionID)] = contractState{
+			levelReached: contract.ProgressionLevelReached,
+			towardsNext:  contract.ProgressionTowardsNextLevel,
+			totalEarned:  contract.ContractProgression.TotalProgressionEarned,
+		}
+	}
+
+	response := &models.ContractsResponse{
+		AgentContracts: []models.ContractProgress{},
+	}
+
+	// 没有获得过经验的玩家可能还没有当前通行证的进度，此时按0级返回
+	if act := catalog.CurrentAct(time.Now()); act != nil {
+		if battlePass := battlePassForAct(catalog, act.UUID); battlePass != nil {
+			progress := newContractProgress(battlePass.UUID, states[strings.ToLower(battlePass.UUID)], catalog)
+			response.BattlePass = &progress
+		}
+	}
+
+	if contracts.ActiveSpecialContract != "" {
+		progress := newContractProgress(contracts.ActiveSpecialContract, states[strings.ToLower(contracts.ActiveSpecialContract)], catalog)
+		response.ActiveContract = &progress
+	}
+
+	for _, contract := range contracts.Contracts {
+		content, ok := catalog.Contracts[strings.ToLower(contract.ContractDefinitionID)]
+		if !ok || content.Content.RelationType != models.ContractRelationAgent {
+			continue
+		}
+		response.AgentContracts = append(response.AgentContracts,
+			newContractProgress(contract.ContractDefinitionID, states[strings.ToLower(contract.ContractDefinitionID)], catalog))
+	}
+	sort.Slice(response.AgentContracts, func(i, j int) bool {
+		return response.AgentContracts[i].Name < response.AgentContracts[j].Name
+	})
+
+	return response, nil
+}
+
+// battlePassForAct 返回小赛季的通行证
+func battlePassForAct(catalog *repositories.Catalog, actID string) *models.ContentContract {
+	for _, contract := range catalog.Contracts {
+		if contract.Content.RelationType == models.ContractRelationSeason && strings.EqualFold(contract.Content.RelationUUID, actID) {
+			return contract
+		}
+	}
+	return nil
+}
+
+// newContractProgress 根据内容目录中的等级计算合约进度，并解析每个等级的奖励
+func newContractProgress(contractID string, state contractState, catalog *repositories.Catalog) models.ContractProgress {
+	progress := models.ContractProgress{
+		ContractID:        contractID,
+		Tier:              state.levelReached,
+		XPTowardsNextTier: state.towardsNext,
+		TotalXPEarned:     state.totalEarned,
+	}
+
+	content, ok := catalog.Contracts[strings.ToLower(contractID)]
+	if !ok {
+		return progress
+	}
+	progress.Name = content.DisplayName
+	progress.Icon = content.DisplayIcon
+	progress.Type = content.Content.RelationType
+	progress.RelationID = content.Content.RelationUUID
+
+	for _, chapter := range content.Content.Chapters {
+		for i, level := range chapter.Levels {
+			tier := models.ContractTier{
+				Tier:     len(progress.Tiers) + 1,
+				XP:       level.XP,
+				Reward:   newContractReward(level.Reward, catalog),
+				Unlocked: len(progress.Tiers) < state.levelReached,
+			}
+			if i == len(chapter.Levels)-1 {
+				for _, reward := range chapter.FreeRewards {
+					tier.FreeRewards = append(tier.FreeRewards, newContractReward(reward, catalog))
+				}
+			}
+			progress.Tiers = append(progress.Tiers, tier)
+		}
+	}
+
+	progress.TotalTiers = len(progress.Tiers)
+	if state.levelReached < progress.TotalTiers {
+		progress.XPForNextTier = progress.Tiers[state.levelReached].XP
+		progress.XPToNextTier = progress.XPForNextTier - state.towardsNext
+		if progress.XPToNextTier < 0 {
+			progress.XPToNextTier = 0
+		}
+	} else {
+		progress.Complete = progress.TotalTiers > 0
+	}
+
+	return progress
+}
+
+// newContractReward 解析合约奖励的名称和图片
+func newContractReward(reward models.ContentContractReward, catalog *repositories.Catalog) models.ContractReward {
+	result := models.ContractReward{
+		Type:   reward.Type,
+		ID:     reward.UUID,
+		Amount: reward.Amount,
+	}
+
+	if reward.Type == "Currency" {
+		result.Name = models.LookupCurrency(reward.UUID).Name
+	} else {
+		result.ItemInfo, _ = catalog.ResolveItem(reward.UUID)
+	}
+	return result
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/emper0r/val-store-server/internal/models"
+	"github.com/emper0r/val-store-server/internal/repositories"
+)
+
+// testContractJSON 两个章节共三个等级的特工合约，第一章完成时获得免费奖励
+const testContractJSON = `{
+	"uuid": "contract-1",
+	"displayName": "Jett",
+	"content": {
+		"relationType": "Agent",
+		"relationUuid": "agent-jett",
+		"chapters": [
+			{
+				"levels": [
+					{"xp": 1000, "reward": {"type": "Spray", "uuid": "spray-1", "amount": 1}},
+					{"xp": 2000, "reward": {"type": "Currency", "uuid": "85ad13f7-3d1b-5128-9eb2-7cd8ee0b5741", "amount": 100}}
+				],
+				"freeRewards": [{"type": "Title", "uuid": "title-1", "amount": 1}]
+			},
+			{
+				"levels": [{"xp": 3000, "reward": {"type": "Spray", "uuid": "spray-unknown", "amount": 1}}]
+			}
+		]
+	}
+}`
+
+// newContractTestCatalog 创建只包含测试合约及其奖励的目录
+func newContractTestCatalog(t *testing.T) *repositories.Catalog {
+	t.Helper()
+
+	var contract models.ContentContract
+	if err := json.Unmarshal([]byte(testContractJSON), &contract); err != nil {
+		t.Fatal(err)
+	}
+	return &repositories.Catalog{
+		Contracts:    map[string]*models.ContentContract{"contract-1": &contract},
+		Sprays:       map[string]*models.ContentSpray{"spray-1": {UUID: "spray-1", DisplayName: "喷漆"}},
+		PlayerTitles: map[string]*models.ContentPlayerTitle{"title-1": {UUID: "title-1", TitleText: "称号"}},
+	}
+}
+
+func TestNewContractProgress(t *testing.T) {
+	catalog := newContractTestCatalog(t)
+
+	tests := []struct {
+		name             string
+		state            contractState
+		wantUnlocked     []bool
+		wantForNext      int
+		wantToNext       int
+		wantComplete     bool
+		wantContractTier int
+	}{
+		{
+			name:         "尚未开始",
+			state:        contractState{},
+			wantUnlocked: []bool{false, false, false},
+			wantForNext:  1000,
+			wantToNext:   1000,
+		},
+		{
+			name:             "第一章完成",
+			state:            contractState{levelReached: 2, towardsNext: 1200, totalEarned: 4200},
+			wantUnlocked:     []bool{true, true, false},
+			wantForNext:      3000,
+			wantToNext:       1800,
+			wantContractTier: 2,
+		},
+		{
+			// Riot在等级结算前可能返回超过下一等级所需的经验
+			name:             "超出所需经验时不为负数",
+			state:            contractState{levelReached: 1, towardsNext: 2500, totalEarned: 3500},
+			wantUnlocked:     []bool{true, false, false},
+			wantForNext:      2000,
+			wantToNext:       0,
+			wantContractTier: 1,
+		},
+		{
+			name:             "全部完成",
+			state:            contractState{levelReached: 3, totalEarned: 6000},
+			wantUnlocked:     []bool{true, true, true},
+			wantComplete:     true,
+			wantContractTier: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			progress := newContractProgress("CONTRACT-1", tt.state, catalog)
+
+			if progress.Name != "Jett" || progress.Type != "Agent" || progress.RelationID != "agent-jett" ||
+				progress.TotalTiers != 3 || progress.Tier != tt.wantContractTier || progress.TotalXPEarned != tt.state.totalEarned {
+				t.Fatalf("合约进度为%+v", progress)
+			}
+
+			unlocked := make([]bool, 0, len(progress.Tiers))
+			for _, tier := range progress.Tiers {
+				unlocked = append(unlocked, tier.Unlocked)
+			}
+			if fmt.Sprint(unlocked) != fmt.Sprint(tt.wantUnlocked) {
+				t.Errorf("解锁状态为%v，期望%v", unlocked, tt.wantUnlocked)
+			}
+			if progress.XPForNextTier != tt.wantForNext || progress.XPToNextTier != tt.wantToNext {
+				t.Errorf("下一等级需要%d经验，还差%d，期望%d和%d",
+					progress.XPForNextTier, progress.XPToNextTier, tt.wantForNext, tt.wantToNext)
+			}
+			if progress.Complete != tt.wantComplete {
+				t.Errorf("Complete为%v，期望%v", progress.Complete, tt.wantComplete)
+			}
+		})
+	}
+}
+
+func TestNewContractProgressRewards(t *testing.T) {
+	progress := newContractProgress("contract-1", contractState{}, newContractTestCatalog(t))
+
+	tiers := progress.Tiers
+	if len(tiers) != 3 || tiers[0].Tier != 1 || tiers[2].Tier != 3 || tiers[2].XP != 3000 {
+		t.Fatalf("等级为%+v", tiers)
+	}
+	if tiers[0].Reward.Name != "喷漆" || tiers[1].Reward.Name != "Valorant Points" || tiers[1].Reward.Amount != 100 {
+		t.Errorf("奖励为%+v和%+v", tiers[0].Reward, tiers[1].Reward)
+	}
+	// 目录中没有的奖励只返回ID
+	if tiers[2].Reward.ID != "spray-unknown" || tiers[2].Reward.Name != "" {
+		t.Errorf("未知奖励为%+v", tiers[2].Reward)
+	}
+
+	// 免费奖励只出现在章节的最后一个等级
+	if len(tiers[0].FreeRewards) != 0 || len(tiers[2].FreeRewards) != 0 ||
+		len(tiers[1].FreeRewards) != 1 || tiers[1].FreeRewards[0].Name != "称号" {
+		t.Errorf("免费奖励为%+v、%+v、%+v", tiers[0].FreeRewards, tiers[1].FreeRewards, tiers[2].FreeRewards)
+	}
+}
+
+func TestNewContractProgressUnknownContract(t *testing.T) {
+	state := contractState{levelReached: 5, towardsNext: 100, totalEarned: 9000}
+	progress := newContractProgress("missing", state, newContractTestCatalog(t))
+
+	// 目录中没有的合约只返回Riot的进度，不能判断是否完成
+	if progress.ContractID != "missing" || progress.Tier != 5 || progress.TotalXPEarned != 9000 ||
+		progress.TotalTiers != 0 || progress.Complete || progress.Tiers != nil {
+		t.Errorf("合约进度为%+v", progress)
+	}
+}
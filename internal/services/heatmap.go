@@ -0,0 +1,199 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"log"
+	"math"
+	"strings"
+
+	"github.com/emper0r/val-store-server/internal/models"
+)
+
+const (
+	// defaultHeatmapMatches 未指定limit时使用最近多少场比赛
+	defaultHeatmapMatches = 50
+
+	// heatmapRadiusRatio 每个点的影响半径占小地图宽度的比例
+	heatmapRadiusRatio = 0.025
+
+	// heatmapMaxAlpha 热度最高处覆盖层的不透明度
+	heatmapMaxAlpha = 0.8
+)
+
+// RenderHeatmap 将存档比赛中玩家击杀或死亡的位置绘制到小地图上，返回PNG图片
+func (s *MatchService) RenderHeatmap(userID string, query *models.HeatmapQuery, language string) ([]byte, error) {
+	if query.Map == "" {
+		return nil, fmt.Errorf("%w: 必须指定地图", ErrInvalidArchiveQuery)
+	}
+	kind := strings.ToLower(query.Type)
+	if kind == "" {
+		kind = models.HeatmapKills
+	}
+	if kind != models.HeatmapKills && kind != models.HeatmapDeaths {
+		return nil, fmt.Errorf("%w: type只能是kills或deaths", ErrInvalidArchiveQuery)
+	}
+
+	// 坐标转换和小地图都来自内容目录
+	catalog := loadCatalog(s.contentCatalog, language)
+	if catalog == nil {
+		return nil, ErrCatalogUnavailable
+	}
+	filter, err := resolveArchiveFilter(&query.MatchArchiveQuery, catalog)
+	if err != nil {
+		return nil, err
+	}
+	contentMap := catalog.FindMap(query.Map)
+
+	minimap, err := s.contentCatalog.MinimapImage(contentMap)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := s.archive.Index(userID)
+	if err != nil {
+		return nil, err
+	}
+	matches := filterArchive(index, filter)
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultHeatmapMatches
+	}
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	var points []models.ValorantLocation
+	for _, match := range matches {
+		details, err := s.archive.Details(userID, match.MatchID)
+		if err != nil {
+			log.Printf("警告: 读取存档比赛 %s 失败: %v", match.MatchID, err)
+			continue
+		}
+		points = append(points, heatmapPoints(details, userID, kind)...)
+	}
+
+	bounds := minimap.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	pixels := make([]image.Point, 0, len(points))
+	for _, point := range points {
+		pixels = append(pixels, minimapPixel(point, contentMap, width, height))
+	}
+
+	canvas := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(canvas, canvas.Bounds(), minimap, bounds.Min, draw.Src)
+	draw.Draw(canvas, canvas.Bounds(), heatmapOverlay(width, height, pixels), image.Point{}, draw.Over)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, canvas); err != nil {
+		return nil, fmt.Errorf("生成热力图失败: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// minimapPixel 将游戏坐标转换为width×height的小地图上的像素位置
+// 与valorant-api.com的约定一致，小地图的x来自游戏坐标的y，小地图的y来自游戏坐标的x
+func minimapPixel(point models.ValorantLocation, contentMap *models.ContentMap, width, height int) image.Point {
+	x := point.Y*contentMap.XMultiplier + contentMap.XScalarToAdd
+	y := point.X*contentMap.YMultiplier + contentMap.YScalarToAdd
+	return image.Pt(int(x*float64(width)), int(y*float64(height)))
+}
+
+// heatmapPoints 返回比赛中玩家击杀时所在的位置或死亡的位置
+func heatmapPoints(details *models.ValorantMatchDetails, puuid, kind string) []models.ValorantLocation {
+	var points []models.ValorantLocation
+	for _, round := range details.RoundResults {
+		for _, playerStats := range round.PlayerStats {
+			for _, kill := range playerStats.Kills {
+				switch {
+				case kind == models.HeatmapDeaths && strings.EqualFold(kill.Victim, puuid):
+					points = append(points, kill.VictimLocation)
+				case kind == models.HeatmapKills && strings.EqualFold(kill.Killer, puuid):
+					for _, location := range kill.PlayerLocations {
+						if strings.EqualFold(location.Subject, puuid) {
+							points = append(points, location.Location)
+							break
+						}
+					}
+				}
+			}
+		}
+	}
+	return points
+}
+
+// heatmapOverlay 将点按高斯核累加为热度，并映射为从蓝到红的半透明颜色
+func heatmapOverlay(width, height int, pixels []image.Point) *image.NRGBA {
+	overlay := image.NewNRGBA(image.Rect(0, 0, width, height))
+	if len(pixels) == 0 {
+		return overlay
+	}
+
+	radius := int(math.Max(2, float64(width)*heatmapRadiusRatio))
+	sigma := float64(radius) / 2
+	density := make([]float64, width*height)
+	maxDensity := 0.0
+	for _, p := range pixels {
+		for dy := -radius; dy <= radius; dy++ {
+			y := p.Y + dy
+			if y < 0 || y >= height {
+				continue
+			}
+			for dx := -radius; dx <= radius; dx++ {
+				x := p.X + dx
+				if x < 0 || x >= width {
+					continue
+				}
+				i := y*width + x
+				density[i] += math.Exp(-float64(dx*dx+dy*dy) / (2 * sigma * sigma))
+				if density[i] > maxDensity {
+					maxDensity = density[i]
+				}
+			}
+		}
+	}
+	if maxDensity == 0 {
+		return overlay
+	}
+
+	for i, value := range density {
+		if value == 0 {
+			continue
+		}
+		t := value / maxDensity
+		c := heatColor(t)
+		c.A = uint8(255 * heatmapMaxAlpha * math.Min(1, t*2))
+		overlay.SetNRGBA(i%width, i/width, c)
+	}
+	return overlay
+}
+
+// heatColor 将0到1的热度映射为蓝、青、绿、黄、红的渐变色
+func heatColor(t float64) color.NRGBA {
+	stops := []color.NRGBA{
+		{0, 0, 255, 0},
+		{0, 255, 255, 0},
+		{0, 255, 0, 0},
+		{255, 255, 0, 0},
+		{255, 0, 0, 0},
+	}
+
+	position := t * float64(len(stops)-1)
+	i := int(position)
+	if i >= len(stops)-1 {
+		return stops[len(stops)-1]
+	}
+	frac := position - float64(i)
+	lerp := func(a, b uint8) uint8 {
+		return uint8(float64(a) + (float64(b)-float64(a))*frac)
+	}
+	return color.NRGBA{
+		R: lerp(stops[i].R, stops[i+1].R),
+		G: lerp(stops[i].G, stops[i+1].G),
+		B: lerp(stops[i].B, stops[i+1].B),
+	}
+}
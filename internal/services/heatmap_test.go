@@ -0,0 +1,122 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"testing"
+
+	"github.com/emper0r/val-store-server/internal/models"
+)
+
+// testHeatmapMatchJSON 两个回合中me的击杀和死亡，击杀位置取击杀时me自己的位置
+const testHeatmapMatchJSON = `{
+	"roundResults": [
+		{
+			"playerStats": [
+				{
+					"subject": "ME",
+					"kills": [
+						{"killer": "ME", "victim": "enemy-1", "victimLocation": {"x": 900, "y": 900},
+							"playerLocations": [
+								{"subject": "ally", "location": {"x": 1, "y": 1}},
+								{"subject": "me", "location": {"x": 100, "y": 200}}
+							]},
+						{"killer": "me", "victim": "enemy-2", "victimLocation": {"x": 800, "y": 800},
+							"playerLocations": [{"subject": "ally", "location": {"x": 2, "y": 2}}]}
+					]
+				},
+				{
+					"subject": "enemy-3",
+					"kills": [
+						{"killer": "enemy-3", "victim": "me", "victimLocation": {"x": 300, "y": 400},
+							"playerLocations": [{"subject": "enemy-3", "location": {"x": 3, "y": 3}}]}
+					]
+				}
+			]
+		},
+		{
+			"playerStats": [
+				{
+					"subject": "ally",
+					"kills": [
+						{"killer": "ally", "victim": "enemy-1", "victimLocation": {"x": 700, "y": 700},
+							"playerLocations": [{"subject": "me", "location": {"x": 4, "y": 4}}]}
+					]
+				},
+				{
+					"subject": "me",
+					"kills": [
+						{"killer": "me", "victim": "enemy-2", "victimLocation": {"x": 600, "y": 600},
+							"playerLocations": [{"subject": "me", "location": {"x": 500, "y": 600}}]}
+					]
+				}
+			]
+		}
+	]
+}`
+
+func TestHeatmapPoints(t *testing.T) {
+	var details models.ValorantMatchDetails
+	if err := json.Unmarshal([]byte(testHeatmapMatchJSON), &details); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		kind string
+		want []models.ValorantLocation
+	}{
+		// 缺少自己位置的击杀被跳过，队友的击杀不计入
+		{name: "击杀", kind: models.HeatmapKills, want: []models.ValorantLocation{{X: 100, Y: 200}, {X: 500, Y: 600}}},
+		{name: "死亡", kind: models.HeatmapDeaths, want: []models.ValorantLocation{{X: 300, Y: 400}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := heatmapPoints(&details, "me", tt.kind)
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("位置为%v，期望%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMinimapPixel(t *testing.T) {
+	// Ascent在valorant-api.com中的坐标参数
+	ascent := &models.ContentMap{
+		XMultiplier:  0.00007,
+		YMultiplier:  -0.00007,
+		XScalarToAdd: 0.813895,
+		YScalarToAdd: 0.573242,
+	}
+	// 两个方向使用不同参数，便于发现x和y用错
+	skewed := &models.ContentMap{
+		XMultiplier:  0.001,
+		YMultiplier:  0.0001,
+		XScalarToAdd: 0.1,
+		YScalarToAdd: 0.2,
+	}
+
+	tests := []struct {
+		name          string
+		contentMap    *models.ContentMap
+		point         models.ValorantLocation
+		width, height int
+		want          image.Point
+	}{
+		{name: "原点", contentMap: ascent, point: models.ValorantLocation{}, width: 1024, height: 1024, want: image.Pt(833, 586)},
+		{name: "小地图x来自游戏y", contentMap: skewed, point: models.ValorantLocation{Y: 500}, width: 1000, height: 1000, want: image.Pt(600, 200)},
+		{name: "小地图y来自游戏x", contentMap: skewed, point: models.ValorantLocation{X: 5000}, width: 1000, height: 1000, want: image.Pt(100, 700)},
+		{name: "负的乘数", contentMap: ascent, point: models.ValorantLocation{X: 2000, Y: -1000}, width: 1000, height: 1000, want: image.Pt(743, 433)},
+		{name: "非正方形", contentMap: skewed, point: models.ValorantLocation{X: 3000, Y: 300}, width: 200, height: 100, want: image.Pt(80, 50)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := minimapPixel(tt.point, tt.contentMap, tt.width, tt.height); got != tt.want {
+				t.Errorf("像素位置为%v，期望%v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/emper0r/val-store-server/internal/models"
+	"github.com/emper0r/val-store-server/internal/repositories"
+)
+
+// InventoryService 处理玩家库存相关的业务逻辑
+type InventoryService struct {
+	valorantAPI    *repositories.ValorantAPI
+	contentCatalog *repositories.ContentCatalog
+}
+
+// NewInventoryService 创建新的库存服务
+func NewInventoryService(valorantAPI *repositories.ValorantAPI, contentCatalog *repositories.ContentCatalog) *InventoryService {
+	return &InventoryService{
+		valorantAPI:    valorantAPI,
+		contentCatalog: contentCatalog,
+	}
+}
+
+// GetInventory 获取玩家拥有的指定类型的物品
+func (s *InventoryService) GetInventory(session *models.UserSession, inventoryType, language string) (*models.InventoryResponse, error) {
+	itemTypeID, ok := models.InventoryTypes[inventoryType]
+	if !ok {
+		return nil, fmt.Errorf("不支持的物品类型: %s", inventoryType)
+	}
+
+	client, err := s.valorantAPI.NewClient(session)
+	if err != nil {
+		return nil, err
+	}
+
+	entitlements, err := client.GetEntitlements(itemTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	return newInventory(inventoryType, itemTypeID, entitlements, loadCatalog(s.contentCatalog, language)), nil
+}
+
+// newInventory 将权益列表转换为库存响应，皮肤等级按所属皮肤合并
+func newInventory(inventoryType, itemTypeID string, entitlements *models.ValorantItemEntitlementsResponse, catalog *repositories.Catalog) *models.InventoryResponse {
+	response := &models.InventoryResponse{
+		Type:  inventoryType,
+		Items: make([]models.InventoryItem, 0, len(entitlements.Entitlements)),
+	}
+
+	// 每个皮肤等级都是单独的权益，按所属皮肤合并，避免同一皮肤重复计数
+	skinIndex := make(map[string]int)
+	for _, entitlement := range entitlements.Entitlements {
+		if itemTypeID == models.ItemTypeSkinLevel {
+			if skin, ok := catalog.ParentSkin(entitlement.ItemID); ok {
+				key := strings.ToLower(skin.UUID)
+				if index, exists := skinIndex[key]; exists {
+					response.Items[index].OwnedLevels = append(response.Items[index].OwnedLevels, entitlement.ItemID)
+					continue
+				}
+
+				info, _ := catalog.ResolveItem(skin.UUID)
+				skinIndex[key] = len(response.Items)
+				response.Items = append(response.Items, models.InventoryItem{
+					ItemInfo:    info,
+					ItemID:      skin.UUID,
+					OwnedLevels: []string{entitlement.ItemID},
+				})
+				continue
+			}
+		}
+
+		info, _ := catalog.ResolveItem(entitlement.ItemID)
+		response.Items = append(response.Items, models.InventoryItem{
+			ItemInfo: info,
+			ItemID:   entitlement.ItemID,
+		})
+	}
+	response.Count = len(response.Items)
+
+	return response
+}
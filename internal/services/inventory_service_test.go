@@ -0,0 +1,106 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/emper0r/val-store-server/internal/models"
+	"github.com/emper0r/val-store-server/internal/repositories"
+)
+
+// newInventoryTestCatalog 创建包含两个皮肤的目录，skin-a有两个等级和一个炫彩
+func newInventoryTestCatalog() *repositories.Catalog {
+	return &repositories.Catalog{
+		Skins: map[string]*models.ContentSkin{
+			"skin-a": {UUID: "skin-a", DisplayName: "Skin A", DisplayIcon: "a.png"},
+			"skin-b": {UUID: "skin-b", DisplayName: "Skin B"},
+		},
+		SkinLevels: map[string]*models.ContentSkinLevel{
+			"level-a1": {UUID: "level-a1", SkinUUID: "skin-a"},
+			"level-a2": {UUID: "level-a2", SkinUUID: "skin-a"},
+			"level-b1": {UUID: "level-b1", SkinUUID: "skin-b"},
+		},
+		Chromas: map[string]*models.ContentChroma{
+			"chroma-a": {UUID: "chroma-a", DisplayName: "Skin A Red", SkinUUID: "skin-a"},
+		},
+	}
+}
+
+// newTestEntitlements 创建指定物品的权益列表
+func newTestEntitlements(t *testing.T, itemIDs ...string) *models.ValorantItemEntitlementsResponse {
+	t.Helper()
+
+	entitlements := make([]map[string]string, 0, len(itemIDs))
+	for _, id := range itemIDs {
+		entitlements = append(entitlements, map[string]string{"ItemID": id})
+	}
+	data, err := json.Marshal(map[string]interface{}{"Entitlements": entitlements})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var response models.ValorantItemEntitlementsResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		t.Fatal(err)
+	}
+	return &response
+}
+
+func TestNewInventory(t *testing.T) {
+	catalog := newInventoryTestCatalog()
+
+	tests := []struct {
+		name          string
+		inventoryType string
+		itemIDs       []string
+		catalog       *repositories.Catalog
+		want          []string // 物品ID及已拥有的等级
+	}{
+		{
+			name:          "皮肤等级按所属皮肤合并",
+			inventoryType: "skins",
+			itemIDs:       []string{"level-a1", "LEVEL-B1", "level-a2"},
+			catalog:       catalog,
+			want:          []string{"skin-a[level-a1 level-a2] Skin A", "skin-b[LEVEL-B1] Skin B"},
+		},
+		{
+			name:          "目录中没有的等级单独列出",
+			inventoryType: "skins",
+			itemIDs:       []string{"level-a1", "level-x"},
+			catalog:       catalog,
+			want:          []string{"skin-a[level-a1] Skin A", "level-x[] "},
+		},
+		{
+			name:          "目录未加载时不合并",
+			inventoryType: "skins",
+			itemIDs:       []string{"level-a1", "level-a2"},
+			want:          []string{"level-a1[] ", "level-a2[] "},
+		},
+		{
+			name:          "炫彩不按皮肤合并",
+			inventoryType: "chromas",
+			itemIDs:       []string{"chroma-a"},
+			catalog:       catalog,
+			want:          []string{"chroma-a[] Skin A Red"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemTypeID := models.InventoryTypes[tt.inventoryType]
+			response := newInventory(tt.inventoryType, itemTypeID, newTestEntitlements(t, tt.itemIDs...), tt.catalog)
+
+			got := make([]string, 0, len(response.Items))
+			for _, item := range response.Items {
+				got = append(got, fmt.Sprintf("%s%v %s", item.ItemID, item.OwnedLevels, item.Name))
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("库存为%q，期望%q", got, tt.want)
+			}
+			if response.Type != tt.inventoryType || response.Count != len(tt.want) {
+				t.Errorf("库存类型或数量不正确: %+v", response)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/emper0r/val-store-server/internal/models"
+	"github.com/emper0r/val-store-server/internal/repositories"
+)
+
+// 配置预设的限制
+const (
+	maxPresetsPerUser = 50
+	maxPresetNameLen  = 32
+)
+
+// ErrPresetNotFound 配置预设不存在
+var ErrPresetNotFound = errors.New("配置预设不存在")
+
+// ErrInvalidPreset 预设名称无效或数量超过限制
+var ErrInvalidPreset = errors.New("无效的配置预设")
+
+// ErrSettingsStorage 读取或写入用户设置失败
+var ErrSettingsStorage = errors.New("读写用户设置失败")
+
+// ListPresets 列出用户保存的配置预设
+func (s *LoadoutService) ListPresets(userID string) ([]models.LoadoutPreset, error) {
+	settings, err := s.settingsStore.Get(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if settings.LoadoutPresets == nil {
+		return []models.LoadoutPreset{}, nil
+	}
+	return settings.LoadoutPresets, nil
+}
+
+// SavePreset 保存配置预设，同名预设会被覆盖；请求中没有配置时保存玩家当前的配置
+func (s *LoadoutService) SavePreset(session *models.UserSession, request *models.SavePresetRequest) (*models.LoadoutPreset, error) {
+	name := strings.TrimSpace(request.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxPresetNameLen {
+		return nil, fmt.Errorf("%w: 预设名称不能为空且不能超过%d个字符", ErrInvalidPreset, maxPresetNameLen)
+	}
+
+	var loadout models.LoadoutUpdateRequest
+	if request.Loadout != nil {
+		loadout = *request.Loadout
+	} else {
+		client, err := s.valorantAPI.NewClient(session)
+		if err != nil {
+			return nil, err
+		}
+		current, err := client.GetLoadout()
+		if err != nil {
+			return nil, err
+		}
+		loadout = loadoutToRequest(current)
+	}
+
+	now := time.Now()
+	preset := models.LoadoutPreset{
+		Name:      name,
+		Loadout:   loadout,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	err := s.settingsStore.Update(session.UserID, func(settings *models.UserSettings) error {
+		for i := range settings.LoadoutPresets {
+			if settings.LoadoutPresets[i].Name == name {
+				preset.CreatedAt = settings.LoadoutPresets[i].CreatedAt
+				settings.LoadoutPresets[i] = preset
+				return nil
+			}
+		}
+
+		if len(settings.LoadoutPresets) >= maxPresetsPerUser {
+			return fmt.Errorf("%w: 最多只能保存%d个配置预设", ErrInvalidPreset, maxPresetsPerUser)
+		}
+		settings.LoadoutPresets = append(settings.LoadoutPresets, preset)
+		return nil
+	})
+	if errors.Is(err, ErrInvalidPreset) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSettingsStorage, err)
+	}
+
+	return &preset, nil
+}
+
+// DeletePreset 删除配置预设
+func (s *LoadoutService) DeletePreset(userID, name string) error {
+	return s.settingsStore.Update(userID, func(settings *models.UserSettings) error {
+		for i := range settings.LoadoutPresets {
+			if settings.LoadoutPresets[i].Name == name {
+				settings.LoadoutPresets = append(settings.LoadoutPresets[:i], settings.LoadoutPresets[i+1:]...)
+				return nil
+			}
+		}
+		return ErrPresetNotFound
+	})
+}
+
+// ApplyPreset 将配置预设应用到Riot的配置上
+// 与UpdateLoadout不同，不再拥有的物品会被跳过并在结果中列出，其余物品照常装备
+func (s *LoadoutService) ApplyPreset(session *models.UserSession, name, language string) (*models.ApplyPresetResponse, error) {
+	settings, err := s.settingsStore.Get(session.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSettingsStorage, err)
+	}
+
+	var preset *models.LoadoutPreset
+	for i := range settings.LoadoutPresets {
+		if settings.LoadoutPresets[i].Name == name {
+			preset = &settings.LoadoutPresets[i]
+			break
+		}
+	}
+	if preset == nil {
+		return nil, ErrPresetNotFound
+	}
+
+	client, err := s.valorantAPI.NewClient(session)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := loadCatalog(s.contentCatalog, language)
+	if catalog == nil {
+		return nil, ErrCatalogUnavailable
+	}
+
+	loadout, err := client.GetLoadout()
+	if err != nil {
+		return nil, err
+	}
+
+	owned, err := fetchOwnedItems(client)
+	if err != nil {
+		return nil, err
+	}
+
+	skipped, changed := applyPreset(loadout, preset, owned, catalog)
+	if !changed {
+		// 所有物品都被跳过或与当前配置相同，不需要提交给Riot
+		return &models.ApplyPresetResponse{
+			Loadout: buildLoadoutResponse(loadout, catalog),
+			Skipped: skipped,
+		}, nil
+	}
+
+	updated, err := client.PutLoadout(loadout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ApplyPresetResponse{
+		Loadout: buildLoadoutResponse(updated, catalog),
+		Skipped: skipped,
+	}, nil
+}
+
+// applyPreset 将预设应用到配置上，返回跳过的物品以及配置是否有变化
+func applyPreset(loadout *models.ValorantLoadout, preset *models.LoadoutPreset, owned *ownedItems, catalog *repositories.Catalog) ([]models.SkippedItem, bool) {
+	original := cloneLoadout(loadout)
+
+	skipped := applyLoadoutChanges(loadout, &preset.Loadout, owned, catalog)
+	if skipped == nil {
+		skipped = []models.SkippedItem{}
+	}
+
+	return skipped, !reflect.DeepEqual(original, loadout)
+}
+
+// cloneLoadout 复制配置中会被修改的部分
+func cloneLoadout(loadout *models.ValorantLoadout) *models.ValorantLoadout {
+	cloned := *loadout
+	if loadout.Guns != nil {
+		cloned.Guns = make([]models.ValorantLoadoutGun, len(loadout.Guns))
+		copy(cloned.Guns, loadout.Guns)
+	}
+	if loadout.Sprays != nil {
+		cloned.Sprays = make([]models.ValorantLoadoutSpray, len(loadout.Sprays))
+		copy(cloned.Sprays, loadout.Sprays)
+	}
+	return &cloned
+}
+
+// loadoutToRequest 将Riot的配置转换为可以重新应用的修改请求
+func loadoutToRequest(loadout *models.ValorantLoadout) models.LoadoutUpdateRequest {
+	request := models.LoadoutUpdateRequest{
+		Guns:    make([]models.GunChange, 0, len(loadout.Guns)),
+		CardID:  loadout.Identity.PlayerCardID,
+		TitleID: loadout.Identity.PlayerTitleID,
+		Sprays:  make([]models.SprayChange, 0, len(loadout.Sprays)),
+	}
+
+	for _, gun := range loadout.Guns {
+		buddyLevelID := gun.CharmLevelID
+		request.Guns = append(request.Guns, models.GunChange{
+			WeaponID:     gun.ID,
+			SkinID:       gun.SkinID,
+			SkinLevelID:  gun.SkinLevelID,
+			ChromaID:     gun.ChromaID,
+			BuddyLevelID: &buddyLevelID,
+		})
+	}
+
+	for _, spray := range loadout.Sprays {
+		if spray.SprayID == "" {
+			continue
+		}
+		request.Sprays = append(request.Sprays, models.SprayChange{
+			SlotID:  spray.EquipSlotID,
+			SprayID: spray.SprayID,
+		})
+	}
+
+	return request
+}
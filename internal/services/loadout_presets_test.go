@@ -0,0 +1,178 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/emper0r/val-store-server/internal/models"
+	"github.com/emper0r/val-store-server/internal/repositories"
+)
+
+// newPresetTestService 创建使用临时目录保存用户设置的配置服务
+func newPresetTestService(t *testing.T) *LoadoutService {
+	t.Helper()
+
+	store, err := repositories.NewFileUserSettingsStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewLoadoutService(nil, nil, store)
+}
+
+// savePreset 保存只包含卡面的预设，请求中带有配置时不会调用Riot
+func savePreset(service *LoadoutService, name, cardID string) (*models.LoadoutPreset, error) {
+	session := &models.UserSession{UserID: "user-1"}
+	return service.SavePreset(session, &models.SavePresetRequest{
+		Name:    name,
+		Loadout: &models.LoadoutUpdateRequest{CardID: cardID},
+	})
+}
+
+func TestSavePresetName(t *testing.T) {
+	tests := []struct {
+		name     string
+		preset   string
+		wantName string
+		wantErr  error
+	}{
+		{name: "去掉首尾空格", preset: "  进攻  ", wantName: "进攻"},
+		{name: "32个字符", preset: strings.Repeat("预", maxPresetNameLen), wantName: strings.Repeat("预", maxPresetNameLen)},
+		{name: "超过32个字符", preset: strings.Repeat("预", maxPresetNameLen+1), wantErr: ErrInvalidPreset},
+		{name: "空名称", preset: "   ", wantErr: ErrInvalidPreset},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newPresetTestService(t)
+
+			preset, err := savePreset(service, tt.preset, "card-1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("错误为%v，期望%v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if preset.Name != tt.wantName || preset.Loadout.CardID != "card-1" {
+				t.Errorf("预设为%+v", preset)
+			}
+		})
+	}
+}
+
+func TestSavePresetLimit(t *testing.T) {
+	service := newPresetTestService(t)
+
+	for i := 0; i < maxPresetsPerUser; i++ {
+		if _, err := savePreset(service, fmt.Sprintf("preset-%d", i), "card-1"); err != nil {
+			t.Fatalf("保存第%d个预设失败: %v", i+1, err)
+		}
+	}
+
+	if _, err := savePreset(service, "one-too-many", "card-1"); !errors.Is(err, ErrInvalidPreset) {
+		t.Errorf("错误为%v，期望%v", err, ErrInvalidPreset)
+	}
+
+	// 达到上限后仍然可以覆盖同名预设，并保留创建时间
+	presets, err := service.ListPresets("user-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	original := presets[0]
+	updated, err := savePreset(service, original.Name, "card-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !updated.CreatedAt.Equal(original.CreatedAt) || updated.Loadout.CardID != "card-2" {
+		t.Errorf("覆盖后的预设为%+v，原预设为%+v", updated, original)
+	}
+
+	presets, err = service.ListPresets("user-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(presets) != maxPresetsPerUser || presets[0].Loadout.CardID != "card-2" {
+		t.Errorf("共有%d个预设，第一个为%+v", len(presets), presets[0])
+	}
+}
+
+func TestApplyPresetNotFound(t *testing.T) {
+	service := newPresetTestService(t)
+	if _, err := savePreset(service, "进攻", "card-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := service.ApplyPreset(&models.UserSession{UserID: "user-1"}, "防守", "")
+	if !errors.Is(err, ErrPresetNotFound) {
+		t.Errorf("错误为%v，期望%v", err, ErrPresetNotFound)
+	}
+}
+
+func TestApplyPreset(t *testing.T) {
+	newLoadout := func() *models.ValorantLoadout {
+		return &models.ValorantLoadout{
+			Identity: models.ValorantIdentity{PlayerCardID: "card-current", PlayerTitleID: "title-current"},
+			Sprays:   []models.ValorantLoadoutSpray{{EquipSlotID: "slot-1", SprayID: "spray-current"}},
+		}
+	}
+	owned := &ownedItems{
+		cards:  map[string]bool{"card-owned": true},
+		titles: map[string]bool{},
+		sprays: map[string]bool{},
+	}
+
+	tests := []struct {
+		name        string
+		preset      models.LoadoutUpdateRequest
+		wantChanged bool
+		wantCard    string
+		wantSkipped []string
+	}{
+		{
+			name:        "部分物品未拥有",
+			preset:      models.LoadoutUpdateRequest{CardID: "card-owned", TitleID: "title-sold"},
+			wantChanged: true,
+			wantCard:    "card-owned",
+			wantSkipped: []string{"title title-sold"},
+		},
+		{
+			name: "所有物品都未拥有",
+			preset: models.LoadoutUpdateRequest{
+				CardID:  "card-sold",
+				TitleID: "title-sold",
+				Sprays:  []models.SprayChange{{SlotID: "slot-1", SprayID: "spray-sold"}},
+			},
+			wantCard:    "card-current",
+			wantSkipped: []string{"card card-sold", "title title-sold", "slot-1 spray-sold"},
+		},
+		{
+			name:        "与当前配置相同",
+			preset:      models.LoadoutUpdateRequest{CardID: "card-current", TitleID: "title-current"},
+			wantCard:    "card-current",
+			wantSkipped: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loadout := newLoadout()
+			skipped, changed := applyPreset(loadout, &models.LoadoutPreset{Loadout: tt.preset}, owned, nil)
+
+			if changed != tt.wantChanged {
+				t.Errorf("changed为%v，期望%v", changed, tt.wantChanged)
+			}
+			if loadout.Identity.PlayerCardID != tt.wantCard {
+				t.Errorf("卡面为%q，期望%q", loadout.Identity.PlayerCardID, tt.wantCard)
+			}
+
+			got := make([]string, 0, len(skipped))
+			for _, item := range skipped {
+				got = append(got, item.Slot+" "+item.ItemID)
+			}
+			if skipped == nil || fmt.Sprint(got) != fmt.Sprint(tt.wantSkipped) {
+				t.Errorf("跳过的物品为%q，期望%q", got, tt.wantSkipped)
+			}
+		})
+	}
+}
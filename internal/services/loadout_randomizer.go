@@ -0,0 +1,205 @@
+package services
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+
+	"github.com/emper0r/val-store-server/internal/models"
+	"github.com/emper0r/val-store-server/internal/repositories"
+)
+
+// ErrUnknownTier 请求中的皮肤品质不存在
+var ErrUnknownTier = errors.New("未知的皮肤品质")
+
+// ErrNoRandomCandidates 没有满足条件的已拥有皮肤
+var ErrNoRandomCandidates = errors.New("没有满足条件的已拥有皮肤")
+
+// RandomizeLoadout 为每把武器随机选择一个已拥有的皮肤和炫彩
+// 没有满足条件的皮肤的武器保持不变；request.Apply为false时只返回预览
+func (s *LoadoutService) RandomizeLoadout(session *models.UserSession, request *models.RandomizeLoadoutRequest, language string) (*models.RandomizeLoadoutResponse, error) {
+	client, err := s.valorantAPI.NewClient(session)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := loadCatalog(s.contentCatalog, language)
+	if catalog == nil {
+		return nil, ErrCatalogUnavailable
+	}
+
+	minRank := -1
+	if request.MinTier != "" {
+		tier := findContentTier(catalog, request.MinTier)
+		if tier == nil {
+			return nil, ErrUnknownTier
+		}
+		minRank = tier.Rank
+	}
+
+	loadout, err := client.GetLoadout()
+	if err != nil {
+		return nil, err
+	}
+
+	owned, err := fetchOwnedItems(client)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := collectRandomCandidates(loadout, request.WeaponIDs, minRank, owned, catalog)
+
+	collectionID := ""
+	if request.SameCollection {
+		collectionID, err = restrictToCollection(loadout, candidates, rand.Intn)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	changes, err := pickRandomSkins(loadout, candidates, owned, rand.Intn)
+	if err != nil {
+		return nil, err
+	}
+
+	if skipped := applyLoadoutChanges(loadout, changes, owned, catalog); len(skipped) > 0 {
+		return nil, &LoadoutValidationError{Skipped: skipped}
+	}
+
+	if request.Apply {
+		loadout, err = client.PutLoadout(loadout)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &models.RandomizeLoadoutResponse{
+		Applied:      request.Apply,
+		CollectionID: collectionID,
+		Loadout:      buildLoadoutResponse(loadout, catalog),
+	}, nil
+}
+
+// collectRandomCandidates 收集每把武器满足条件的已拥有皮肤，weaponIDs为空时包括所有武器
+func collectRandomCandidates(loadout *models.ValorantLoadout, weaponIDs []string, minRank int, owned *ownedItems, catalog *repositories.Catalog) map[string][]*models.ContentSkin {
+	weaponFilter := make(map[string]bool, len(weaponIDs))
+	for _, weaponID := range weaponIDs {
+		weaponFilter[strings.ToLower(weaponID)] = true
+	}
+
+	candidates := make(map[string][]*models.ContentSkin)
+	for _, gun := range loadout.Guns {
+		if len(weaponFilter) > 0 && !weaponFilter[strings.ToLower(gun.ID)] {
+			continue
+		}
+		weapon := catalog.Weapons[strings.ToLower(gun.ID)]
+		if weapon == nil {
+			continue
+		}
+		for i := range weapon.Skins {
+			skin := &weapon.Skins[i]
+			if isSkinOwned(skin, owned) && skinMeetsTier(skin, minRank, catalog) {
+				candidates[gun.ID] = append(candidates[gun.ID], skin)
+			}
+		}
+	}
+	return candidates
+}
+
+// restrictToCollection 随机选择一个系列，并只保留该系列的皮肤，返回系列ID
+// 系列按武器在配置中的顺序收集，intn用于随机选择
+func restrictToCollection(loadout *models.ValorantLoadout, candidates map[string][]*models.ContentSkin, intn func(int) int) (string, error) {
+	var themes []string
+	seen := make(map[string]bool)
+	for _, gun := range loadout.Guns {
+		for _, skin := range candidates[gun.ID] {
+			if skin.ThemeUUID != "" && !seen[skin.ThemeUUID] {
+				seen[skin.ThemeUUID] = true
+				themes = append(themes, skin.ThemeUUID)
+			}
+		}
+	}
+	if len(themes) == 0 {
+		return "", ErrNoRandomCandidates
+	}
+	collectionID := themes[intn(len(themes))]
+
+	for weaponID, skins := range candidates {
+		filtered := skins[:0]
+		for _, skin := range skins {
+			if skin.ThemeUUID == collectionID {
+				filtered = append(filtered, skin)
+			}
+		}
+		candidates[weaponID] = filtered
+	}
+	return collectionID, nil
+}
+
+// pickRandomSkins 为每把有候选皮肤的武器随机选择皮肤和炫彩
+func pickRandomSkins(loadout *models.ValorantLoadout, candidates map[string][]*models.ContentSkin, owned *ownedItems, intn func(int) int) (*models.LoadoutUpdateRequest, error) {
+	changes := &models.LoadoutUpdateRequest{}
+	for _, gun := range loadout.Guns {
+		skins := candidates[gun.ID]
+		if len(skins) == 0 {
+			continue
+		}
+		skin := skins[intn(len(skins))]
+		changes.Guns = append(changes.Guns, models.GunChange{
+			WeaponID: gun.ID,
+			SkinID:   skin.UUID,
+			ChromaID: randomOwnedChroma(skin, owned, intn),
+		})
+	}
+	if len(changes.Guns) == 0 {
+		return nil, ErrNoRandomCandidates
+	}
+	return changes, nil
+}
+
+// findContentTier 按UUID或名称查找皮肤品质，名称不区分大小写
+func findContentTier(catalog *repositories.Catalog, value string) *models.ContentTier {
+	if tier, ok := catalog.ContentTiers[strings.ToLower(value)]; ok {
+		return tier
+	}
+	for _, tier := range catalog.ContentTiers {
+		if strings.EqualFold(tier.DevName, value) || strings.EqualFold(tier.DisplayName, value) {
+			return tier
+		}
+	}
+	return nil
+}
+
+// isSkinOwned 判断是否拥有皮肤的任意等级，默认皮肤不参与随机
+func isSkinOwned(skin *models.ContentSkin, owned *ownedItems) bool {
+	for _, level := range skin.Levels {
+		if owned.skinLevels[strings.ToLower(level.UUID)] {
+			return true
+		}
+	}
+	return false
+}
+
+// skinMeetsTier 判断皮肤品质是否不低于minRank，minRank为负数表示不限制
+func skinMeetsTier(skin *models.ContentSkin, minRank int, catalog *repositories.Catalog) bool {
+	if minRank < 0 {
+		return true
+	}
+	tier, ok := catalog.ContentTiers[strings.ToLower(skin.ContentTierUUID)]
+	return ok && tier.Rank >= minRank
+}
+
+// randomOwnedChroma 在默认炫彩和已拥有的炫彩中随机选择一个
+func randomOwnedChroma(skin *models.ContentSkin, owned *ownedItems, intn func(int) int) string {
+	if len(skin.Chromas) == 0 {
+		return ""
+	}
+
+	chromas := []string{skin.Chromas[0].UUID}
+	for _, chroma := range skin.Chromas[1:] {
+		if owned.chromas[strings.ToLower(chroma.UUID)] {
+			chromas = append(chromas, chroma.UUID)
+		}
+	}
+	return chromas[intn(len(chromas))]
+}
@@ -0,0 +1,228 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/emper0r/val-store-server/internal/models"
+	"github.com/emper0r/val-store-server/internal/repositories"
+)
+
+// newRandomizerCatalog 创建包含两把武器、两个品质和两个系列的目录
+// 皮肤ID格式为<武器>-<系列>-<品质>，等级ID为皮肤ID加上-lv1
+func newRandomizerCatalog() *repositories.Catalog {
+	newSkin := func(uuid, theme, tier string) models.ContentSkin {
+		return models.ContentSkin{
+			UUID:            uuid,
+			ThemeUUID:       theme,
+			ContentTierUUID: tier,
+			Levels:          []models.ContentSkinLevel{{UUID: uuid + "-lv1"}},
+			Chromas:         []models.ContentChroma{{UUID: uuid + "-c0"}, {UUID: uuid + "-c1"}},
+		}
+	}
+
+	return &repositories.Catalog{
+		Weapons: map[string]*models.ContentWeapon{
+			"vandal": {UUID: "vandal", Skins: []models.ContentSkin{
+				newSkin("vandal-prime-premium", "prime", "premium"),
+				newSkin("vandal-reaver-exclusive", "reaver", "exclusive"),
+				newSkin("vandal-none-unknown", "", "unknown"),
+			}},
+			"phantom": {UUID: "phantom", Skins: []models.ContentSkin{
+				newSkin("phantom-reaver-premium", "reaver", "premium"),
+				newSkin("phantom-prime-exclusive", "prime", "exclusive"),
+			}},
+		},
+		ContentTiers: map[string]*models.ContentTier{
+			"premium":   {UUID: "premium", DevName: "Premium", DisplayName: "高级", Rank: 2},
+			"exclusive": {UUID: "exclusive", DevName: "Exclusive", DisplayName: "独家", Rank: 3},
+		},
+	}
+}
+
+// newRandomizerOwned 创建拥有指定皮肤第一个等级的已拥有物品
+func newRandomizerOwned(skinIDs ...string) *ownedItems {
+	owned := &ownedItems{skinLevels: map[string]bool{}, chromas: map[string]bool{}}
+	for _, skinID := range skinIDs {
+		owned.skinLevels[skinID+"-lv1"] = true
+	}
+	return owned
+}
+
+// candidateIDs 将候选皮肤转换为排序后的"武器:皮肤"列表
+func candidateIDs(candidates map[string][]*models.ContentSkin) []string {
+	var ids []string
+	for weaponID, skins := range candidates {
+		for _, skin := range skins {
+			ids = append(ids, weaponID+":"+skin.UUID)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestCollectRandomCandidates(t *testing.T) {
+	loadout := &models.ValorantLoadout{Guns: []models.ValorantLoadoutGun{
+		{ID: "Vandal"}, {ID: "phantom"}, {ID: "classic"},
+	}}
+	allOwned := newRandomizerOwned("vandal-prime-premium", "vandal-reaver-exclusive", "vandal-none-unknown",
+		"phantom-reaver-premium", "phantom-prime-exclusive")
+
+	tests := []struct {
+		name      string
+		weaponIDs []string
+		minRank   int
+		owned     *ownedItems
+		want      []string
+	}{
+		{
+			name:    "不限制",
+			minRank: -1,
+			owned:   allOwned,
+			want: []string{"Vandal:vandal-none-unknown", "Vandal:vandal-prime-premium", "Vandal:vandal-reaver-exclusive",
+				"phantom:phantom-prime-exclusive", "phantom:phantom-reaver-premium"},
+		},
+		{
+			name:      "只随机指定武器",
+			weaponIDs: []string{"PHANTOM"},
+			minRank:   -1,
+			owned:     allOwned,
+			want:      []string{"phantom:phantom-prime-exclusive", "phantom:phantom-reaver-premium"},
+		},
+		{
+			name:    "最低品质排除未知品质",
+			minRank: 3,
+			owned:   allOwned,
+			want:    []string{"Vandal:vandal-reaver-exclusive", "phantom:phantom-prime-exclusive"},
+		},
+		{
+			name:    "排除未拥有的皮肤",
+			minRank: 2,
+			owned:   newRandomizerOwned("vandal-prime-premium", "vandal-none-unknown"),
+			want:    []string{"Vandal:vandal-prime-premium"},
+		},
+		{
+			name:    "没有拥有的皮肤",
+			minRank: -1,
+			owned:   newRandomizerOwned(),
+			want:    nil,
+		},
+		{
+			name:    "拥有的皮肤都不满足品质",
+			minRank: 3,
+			owned:   newRandomizerOwned("vandal-prime-premium", "phantom-reaver-premium"),
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidates := collectRandomCandidates(loadout, tt.weaponIDs, tt.minRank, tt.owned, newRandomizerCatalog())
+
+			if got := candidateIDs(candidates); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("候选皮肤为%v，期望%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRestrictToCollection(t *testing.T) {
+	loadout := &models.ValorantLoadout{Guns: []models.ValorantLoadoutGun{{ID: "vandal"}, {ID: "phantom"}}}
+
+	tests := []struct {
+		name     string
+		owned    *ownedItems
+		pick     int
+		wantID   string
+		want     []string
+		wantErr  error
+		wantPick int
+	}{
+		{
+			name:     "系列按武器顺序收集",
+			owned:    newRandomizerOwned("vandal-prime-premium", "vandal-reaver-exclusive", "phantom-reaver-premium"),
+			pick:     1,
+			wantPick: 2,
+			wantID:   "reaver",
+			want:     []string{"phantom:phantom-reaver-premium", "vandal:vandal-reaver-exclusive"},
+		},
+		{
+			name:     "其他武器没有该系列时不参与随机",
+			owned:    newRandomizerOwned("vandal-prime-premium", "phantom-reaver-premium"),
+			pick:     0,
+			wantPick: 2,
+			wantID:   "prime",
+			want:     []string{"vandal:vandal-prime-premium"},
+		},
+		{
+			name:    "没有系列的皮肤不参与",
+			owned:   newRandomizerOwned("vandal-none-unknown"),
+			wantErr: ErrNoRandomCandidates,
+		},
+		{
+			name:    "没有候选皮肤",
+			owned:   newRandomizerOwned(),
+			wantErr: ErrNoRandomCandidates,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidates := collectRandomCandidates(loadout, nil, -1, tt.owned, newRandomizerCatalog())
+
+			gotPick := 0
+			collectionID, err := restrictToCollection(loadout, candidates, func(n int) int {
+				gotPick = n
+				return tt.pick
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("错误为%v，期望%v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if gotPick != tt.wantPick || collectionID != tt.wantID {
+				t.Errorf("从%d个系列中选择了%q，期望从%d个中选择%q", gotPick, collectionID, tt.wantPick, tt.wantID)
+			}
+			if got := candidateIDs(candidates); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("候选皮肤为%v，期望%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPickRandomSkins(t *testing.T) {
+	loadout := &models.ValorantLoadout{Guns: []models.ValorantLoadoutGun{{ID: "vandal"}, {ID: "phantom"}}}
+	catalog := newRandomizerCatalog()
+	first := func(int) int { return 0 }
+
+	// 只有vandal有候选皮肤，phantom保持不变；未拥有的炫彩不参与
+	owned := newRandomizerOwned("vandal-prime-premium")
+	changes, err := pickRandomSkins(loadout, collectRandomCandidates(loadout, nil, -1, owned, catalog), owned, func(n int) int {
+		return n - 1
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes.Guns) != 1 || changes.Guns[0] != (models.GunChange{
+		WeaponID: "vandal", SkinID: "vandal-prime-premium", ChromaID: "vandal-prime-premium-c0",
+	}) {
+		t.Errorf("修改为%+v", changes.Guns)
+	}
+
+	owned.chromas["vandal-prime-premium-c1"] = true
+	changes, _ = pickRandomSkins(loadout, collectRandomCandidates(loadout, nil, -1, owned, catalog), owned, func(n int) int {
+		return n - 1
+	})
+	if changes.Guns[0].ChromaID != "vandal-prime-premium-c1" {
+		t.Errorf("炫彩为%q，期望已拥有的炫彩", changes.Guns[0].ChromaID)
+	}
+
+	// 没有任何已拥有的皮肤满足条件
+	none := newRandomizerOwned()
+	if _, err := pickRandomSkins(loadout, collectRandomCandidates(loadout, nil, -1, none, catalog), none, first); !errors.Is(err, ErrNoRandomCandidates) {
+		t.Errorf("错误为%v，期望%v", err, ErrNoRandomCandidates)
+	}
+}
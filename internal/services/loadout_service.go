@@ -0,0 +1,452 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/emper0r/val-store-server/internal/models"
+	"github.com/emper0r/val-store-server/internal/repositories"
+)
+
+// ErrCatalogUnavailable 游戏内容尚未加载，无法校验物品归属
+var ErrCatalogUnavailable = errors.New("游戏内容尚未加载，请稍后重试")
+
+// LoadoutValidationError 请求中包含无法装备的物品
+type LoadoutValidationError struct {
+	Skipped []models.SkippedItem
+}
+
+// Error 实现error接口
+func (e *LoadoutValidationError) Error() string {
+	parts := make([]string, 0, len(e.Skipped))
+	for _, item := range e.Skipped {
+		parts = append(parts, fmt.Sprintf("%s(%s): %s", item.ItemID, item.Slot, item.Reason))
+	}
+	return "以下物品无法装备: " + strings.Join(parts, "; ")
+}
+
+// LoadoutService 处理玩家配置相关的业务逻辑
+type LoadoutService struct {
+	valorantAPI    *repositories.ValorantAPI
+	contentCatalog *repositories.ContentCatalog
+	settingsStore  repositories.UserSettingsStore
+}
+
+// NewLoadoutService 创建新的配置服务
+func NewLoadoutService(valorantAPI *repositories.ValorantAPI, contentCatalog *repositories.ContentCatalog, settingsStore repositories.UserSettingsStore) *LoadoutService {
+	return &LoadoutService{
+		valorantAPI:    valorantAPI,
+		contentCatalog: contentCatalog,
+		settingsStore:  settingsStore,
+	}
+}
+
+// GetLoadout 获取玩家当前装备的武器外观、卡面、称号和喷漆
+func (s *LoadoutService) GetLoadout(session *models.UserSession, language string) (*models.LoadoutResponse, error) {
+	client, err := s.valorantAPI.NewClient(session)
+	if err != nil {
+		return nil, err
+	}
+
+	loadout, err := client.GetLoadout()
+	if err != nil {
+		return nil, err
+	}
+
+	return buildLoadoutResponse(loadout, loadCatalog(s.contentCatalog, language)), nil
+}
+
+// UpdateLoadout 校验请求中的物品都已拥有后修改配置
+// 只要有一个物品无法装备就不会提交任何修改，并返回LoadoutValidationError
+func (s *LoadoutService) UpdateLoadout(session *models.UserSession, request *models.LoadoutUpdateRequest, language string) (*models.LoadoutResponse, error) {
+	client, err := s.valorantAPI.NewClient(session)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := loadCatalog(s.contentCatalog, language)
+	if catalog == nil {
+		return nil, ErrCatalogUnavailable
+	}
+
+	loadout, err := client.GetLoadout()
+	if err != nil {
+		return nil, err
+	}
+
+	owned, err := fetchOwnedItems(client)
+	if err != nil {
+		return nil, err
+	}
+
+	if skipped := applyLoadoutChanges(loadout, request, owned, catalog); len(skipped) > 0 {
+		return nil, &LoadoutValidationError{Skipped: skipped}
+	}
+
+	updated, err := client.PutLoadout(loadout)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildLoadoutResponse(updated, catalog), nil
+}
+
+// ownedItems 玩家拥有的物品，所有键都是小写UUID
+type ownedItems struct {
+	skinLevels map[string]bool
+	chromas    map[string]bool
+	buddies    map[string][]string // 枪饰等级ID -> 实例ID列表
+	sprays     map[string]bool
+	cards      map[string]bool
+	titles     map[string]bool
+}
+
+// fetchOwnedItems 通过权益接口获取配置相关的所有已拥有物品
+func fetchOwnedItems(client *repositories.RiotClient) (*ownedItems, error) {
+	owned := &ownedItems{
+		skinLevels: make(map[string]bool),
+		chromas:    make(map[string]bool),
+		buddies:    make(map[string][]string),
+		sprays:     make(map[string]bool),
+		cards:      make(map[string]bool),
+		titles:     make(map[string]bool),
+	}
+
+	sets := []struct {
+		itemTypeID string
+		set        map[string]bool
+	}{
+		{models.ItemTypeSkinLevel, owned.skinLevels},
+		{models.ItemTypeSkinChroma, owned.chromas},
+		{models.ItemTypeSpray, owned.sprays},
+		{models.ItemTypePlayerCard, owned.cards},
+		{models.ItemTypeTitle, owned.titles},
+	}
+	for _, entry := range sets {
+		entitlements, err := client.GetEntitlements(entry.itemTypeID)
+		if err != nil {
+			return nil, err
+		}
+		for _, entitlement := range entitlements.Entitlements {
+			entry.set[strings.ToLower(entitlement.ItemID)] = true
+		}
+	}
+
+	// 枪饰装备时需要指定实例ID，同一枪饰可能拥有多个实例
+	buddies, err := client.GetEntitlements(models.ItemTypeBuddy)
+	if err != nil {
+		return nil, err
+	}
+	for _, entitlement := range buddies.Entitlements {
+		key := strings.ToLower(entitlement.ItemID)
+		owned.buddies[key] = append(owned.buddies[key], entitlement.InstanceID)
+	}
+
+	return owned, nil
+}
+
+// applyLoadoutChanges 将修改应用到配置上，无法装备的物品不会修改并在返回值中列出
+func applyLoadoutChanges(loadout *models.ValorantLoadout, request *models.LoadoutUpdateRequest, owned *ownedItems, catalog *repositories.Catalog) []models.SkippedItem {
+	var skipped []models.SkippedItem
+	skip := func(slot, itemID, reason string) {
+		skipped = append(skipped, models.SkippedItem{Slot: slot, ItemID: itemID, Reason: reason})
+	}
+
+	// 先卸下所有要修改枪饰的武器，使实例按请求的最终状态分配，与武器的处理顺序无关
+	released := releaseBuddies(loadout, request.Guns)
+
+	for _, change := range request.Guns {
+		gun := findGun(loadout, change.WeaponID)
+		if gun == nil {
+			skip(change.WeaponID, change.WeaponID, "配置中没有该武器")
+			continue
+		}
+
+		if change.SkinID != "" || change.SkinLevelID != "" || change.ChromaID != "" {
+			if itemID, reason := applySkinChange(gun, change, owned, catalog); reason != "" {
+				skip(change.WeaponID, itemID, reason)
+			}
+		}
+
+		if change.BuddyLevelID != nil {
+			if reason := applyBuddyChange(loadout, gun, *change.BuddyLevelID, owned, catalog); reason != "" {
+				skip(change.WeaponID, *change.BuddyLevelID, reason)
+			}
+		}
+	}
+	restoreBuddies(loadout, released)
+
+	if request.CardID != "" {
+		if owned.cards[strings.ToLower(request.CardID)] || strings.EqualFold(request.CardID, loadout.Identity.PlayerCardID) {
+			loadout.Identity.PlayerCardID = request.CardID
+		} else {
+			skip("card", request.CardID, "未拥有该卡面")
+		}
+	}
+
+	if request.TitleID != "" {
+		if owned.titles[strings.ToLower(request.TitleID)] || strings.EqualFold(request.TitleID, loadout.Identity.PlayerTitleID) {
+			loadout.Identity.PlayerTitleID = request.TitleID
+		} else {
+			skip("title", request.TitleID, "未拥有该称号")
+		}
+	}
+
+	for _, change := range request.Sprays {
+		applied := false
+		for i := range loadout.Sprays {
+			spray := &loadout.Sprays[i]
+			if !strings.EqualFold(spray.EquipSlotID, change.SlotID) {
+				continue
+			}
+			if !owned.sprays[strings.ToLower(change.SprayID)] && !isSprayEquipped(loadout, change.SprayID) {
+				skip(change.SlotID, change.SprayID, "未拥有该喷漆")
+			} else {
+				spray.SprayID = change.SprayID
+				spray.SprayLevelID = nil
+			}
+			applied = true
+			break
+		}
+		if !applied {
+			skip(change.SlotID, change.SprayID, "配置中没有该喷漆槽位")
+		}
+	}
+
+	return skipped
+}
+
+// findGun 在配置中查找武器
+func findGun(loadout *models.ValorantLoadout, weaponID string) *models.ValorantLoadoutGun {
+	for i := range loadout.Guns {
+		if strings.EqualFold(loadout.Guns[i].ID, weaponID) {
+			return &loadout.Guns[i]
+		}
+	}
+	return nil
+}
+
+// isSprayEquipped 判断喷漆是否已经装备在某个槽位上
+func isSprayEquipped(loadout *models.ValorantLoadout, sprayID string) bool {
+	for _, spray := range loadout.Sprays {
+		if strings.EqualFold(spray.SprayID, sprayID) {
+			return true
+		}
+	}
+	return false
+}
+
+// applySkinChange 修改武器皮肤，失败时返回出问题的物品ID和原因
+func applySkinChange(gun *models.ValorantLoadoutGun, change models.GunChange, owned *ownedItems, catalog *repositories.Catalog) (string, string) {
+	// 确定皮肤，可以由皮肤、等级或炫彩推断
+	var skin *models.ContentSkin
+	switch {
+	case change.SkinID != "":
+		skin = catalog.Skins[strings.ToLower(change.SkinID)]
+	case change.SkinLevelID != "":
+		skin, _ = catalog.ParentSkin(change.SkinLevelID)
+	default:
+		skin, _ = catalog.ParentSkin(change.ChromaID)
+	}
+	requested := firstNonEmpty(change.SkinID, change.SkinLevelID, change.ChromaID)
+	if skin == nil {
+		return requested, "未知的皮肤"
+	}
+	if !strings.EqualFold(skin.WeaponUUID, gun.ID) {
+		return requested, "皮肤不属于该武器"
+	}
+
+	// 武器的默认皮肤所有玩家都拥有
+	weapon := catalog.Weapons[strings.ToLower(gun.ID)]
+	isDefault := weapon != nil && strings.EqualFold(weapon.DefaultSkinUUID, skin.UUID)
+	levelOwned := func(levelID string) bool {
+		return isDefault || owned.skinLevels[strings.ToLower(levelID)] || strings.EqualFold(levelID, gun.SkinLevelID)
+	}
+
+	// 确定等级，未指定时使用已拥有的最高等级
+	levelID := change.SkinLevelID
+	if levelID != "" {
+		if parent, ok := catalog.ParentSkin(levelID); !ok || parent != skin {
+			return levelID, "等级不属于该皮肤"
+		}
+		if !levelOwned(levelID) {
+			return levelID, "未拥有该皮肤等级"
+		}
+	} else {
+		for i := len(skin.Levels) - 1; i >= 0; i-- {
+			if levelOwned(skin.Levels[i].UUID) {
+				levelID = skin.Levels[i].UUID
+				break
+			}
+		}
+		if levelID == "" {
+			return skin.UUID, "未拥有该皮肤"
+		}
+	}
+
+	// 确定炫彩，未指定时使用默认炫彩；默认炫彩随皮肤一起拥有
+	chromaID := change.ChromaID
+	if chromaID == "" {
+		if len(skin.Chromas) == 0 {
+			return skin.UUID, "皮肤没有可用的炫彩"
+		}
+		chromaID = skin.Chromas[0].UUID
+	} else {
+		if parent, ok := catalog.ParentSkin(chromaID); !ok || parent != skin {
+			return chromaID, "炫彩不属于该皮肤"
+		}
+		isBase := strings.EqualFold(chromaID, skin.Chromas[0].UUID)
+		if !isBase && !isDefault && !owned.chromas[strings.ToLower(chromaID)] && !strings.EqualFold(chromaID, gun.ChromaID) {
+			return chromaID, "未拥有该炫彩"
+		}
+	}
+
+	gun.SkinID = skin.UUID
+	gun.SkinLevelID = levelID
+	gun.ChromaID = chromaID
+	return "", ""
+}
+
+// releasedBuddy 修改前装备在武器上的枪饰
+type releasedBuddy struct {
+	gun        *models.ValorantLoadoutGun
+	requested  string // 请求的枪饰等级，空字符串表示卸下
+	instanceID string
+	charmID    string
+	levelID    string
+}
+
+// releaseBuddies 卸下请求中要修改枪饰的武器上的枪饰，返回原来的枪饰
+func releaseBuddies(loadout *models.ValorantLoadout, changes []models.GunChange) []releasedBuddy {
+	var released []releasedBuddy
+	for _, change := range changes {
+		if change.BuddyLevelID == nil {
+			continue
+		}
+		gun := findGun(loadout, change.WeaponID)
+		if gun == nil || gun.CharmInstanceID == "" {
+			continue
+		}
+		released = append(released, releasedBuddy{
+			gun:        gun,
+			requested:  *change.BuddyLevelID,
+			instanceID: gun.CharmInstanceID,
+			charmID:    gun.CharmID,
+			levelID:    gun.CharmLevelID,
+		})
+		gun.CharmInstanceID = ""
+		gun.CharmID = ""
+		gun.CharmLevelID = ""
+	}
+	return released
+}
+
+// restoreBuddies 新枪饰装备失败的武器恢复原来的枪饰，原来的实例已被其他武器使用时保持卸下
+func restoreBuddies(loadout *models.ValorantLoadout, released []releasedBuddy) {
+	for _, buddy := range released {
+		if buddy.requested == "" || buddy.gun.CharmInstanceID != "" || buddyInstanceUsed(loadout, buddy.instanceID) {
+			continue
+		}
+		buddy.gun.CharmInstanceID = buddy.instanceID
+		buddy.gun.CharmID = buddy.charmID
+		buddy.gun.CharmLevelID = buddy.levelID
+	}
+}
+
+// buddyInstanceUsed 判断枪饰实例是否已装备在某把武器上
+func buddyInstanceUsed(loadout *models.ValorantLoadout, instanceID string) bool {
+	for _, gun := range loadout.Guns {
+		if strings.EqualFold(gun.CharmInstanceID, instanceID) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyBuddyChange 修改武器枪饰，空字符串表示卸下，失败时返回原因
+func applyBuddyChange(loadout *models.ValorantLoadout, gun *models.ValorantLoadoutGun, levelID string, owned *ownedItems, catalog *repositories.Catalog) string {
+	if levelID == "" {
+		gun.CharmInstanceID = ""
+		gun.CharmID = ""
+		gun.CharmLevelID = ""
+		return ""
+	}
+
+	level, ok := catalog.BuddyLevels[strings.ToLower(levelID)]
+	if !ok {
+		return "未知的枪饰"
+	}
+
+	// 每个实例只能装备在一把武器上，寻找一个未被其他武器占用的实例
+	used := make(map[string]bool)
+	for i := range loadout.Guns {
+		other := &loadout.Guns[i]
+		if other != gun && other.CharmInstanceID != "" {
+			used[strings.ToLower(other.CharmInstanceID)] = true
+		}
+	}
+
+	for _, instanceID := range owned.buddies[strings.ToLower(levelID)] {
+		if !used[strings.ToLower(instanceID)] {
+			gun.CharmInstanceID = instanceID
+			gun.CharmID = level.BuddyUUID
+			gun.CharmLevelID = level.UUID
+			return ""
+		}
+	}
+
+	if len(owned.buddies[strings.ToLower(levelID)]) == 0 {
+		return "未拥有该枪饰"
+	}
+	return "该枪饰的所有副本都已装备在其他武器上"
+}
+
+// firstNonEmpty 返回第一个非空字符串
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// newLoadoutItem 创建带展示信息的配置物品
+func newLoadoutItem(id string, catalog *repositories.Catalog) models.LoadoutItem {
+	info, _ := catalog.ResolveItem(id)
+	return models.LoadoutItem{ItemInfo: info, ID: id}
+}
+
+// buildLoadoutResponse 将Riot的配置转换为返回给客户端的格式
+func buildLoadoutResponse(loadout *models.ValorantLoadout, catalog *repositories.Catalog) *models.LoadoutResponse {
+	response := &models.LoadoutResponse{
+		Guns:   make([]models.LoadoutGun, 0, len(loadout.Guns)),
+		Card:   newLoadoutItem(loadout.Identity.PlayerCardID, catalog),
+		Title:  newLoadoutItem(loadout.Identity.PlayerTitleID, catalog),
+		Sprays: make([]models.LoadoutSpray, 0, len(loadout.Sprays)),
+	}
+
+	for _, gun := range loadout.Guns {
+		loadoutGun := models.LoadoutGun{
+			Weapon:    newLoadoutItem(gun.ID, catalog),
+			Skin:      newLoadoutItem(gun.SkinID, catalog),
+			SkinLevel: newLoadoutItem(gun.SkinLevelID, catalog),
+			Chroma:    newLoadoutItem(gun.ChromaID, catalog),
+		}
+		if gun.CharmLevelID != "" {
+			buddy := newLoadoutItem(gun.CharmLevelID, catalog)
+			loadoutGun.Buddy = &buddy
+		}
+		response.Guns = append(response.Guns, loadoutGun)
+	}
+
+	for _, spray := range loadout.Sprays {
+		response.Sprays = append(response.Sprays, models.LoadoutSpray{
+			LoadoutItem: newLoadoutItem(spray.SprayID, catalog),
+			SlotID:      spray.EquipSlotID,
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/emper0r/val-store-server/internal/models"
+	"github.com/emper0r/val-store-server/internal/repositories"
+)
+
+// newBuddyTestLoadout 创建两把武器的配置，枪饰实例inst-1装备在gun-a上
+func newBuddyTestLoadout() *models.ValorantLoadout {
+	return &models.ValorantLoadout{
+		Guns: []models.ValorantLoadoutGun{
+			{ID: "gun-a", CharmInstanceID: "inst-1", CharmID: "buddy", CharmLevelID: "level-1"},
+			{ID: "gun-b"},
+		},
+	}
+}
+
+func TestApplyLoadoutChangesMovesBuddy(t *testing.T) {
+	catalog := &repositories.Catalog{
+		BuddyLevels: map[string]*models.ContentBuddyLevel{
+			"level-1": {UUID: "level-1", BuddyUUID: "buddy"},
+		},
+	}
+	owned := &ownedItems{buddies: map[string][]string{"level-1": {"inst-1"}}}
+	unequip := ""
+	level := "level-1"
+	unknown := "level-unknown"
+
+	tests := []struct {
+		name        string
+		changes     []models.GunChange
+		wantA       string
+		wantB       string
+		wantSkipped int
+	}{
+		{
+			name:    "先卸下再装备",
+			changes: []models.GunChange{{WeaponID: "gun-a", BuddyLevelID: &unequip}, {WeaponID: "gun-b", BuddyLevelID: &level}},
+			wantB:   "inst-1",
+		},
+		{
+			name:    "先装备再卸下",
+			changes: []models.GunChange{{WeaponID: "gun-b", BuddyLevelID: &level}, {WeaponID: "gun-a", BuddyLevelID: &unequip}},
+			wantB:   "inst-1",
+		},
+		{
+			name:        "唯一的副本仍在其他武器上",
+			changes:     []models.GunChange{{WeaponID: "gun-b", BuddyLevelID: &level}},
+			wantA:       "inst-1",
+			wantSkipped: 1,
+		},
+		{
+			name:        "装备失败时恢复原来的枪饰",
+			changes:     []models.GunChange{{WeaponID: "gun-a", BuddyLevelID: &unknown}},
+			wantA:       "inst-1",
+			wantSkipped: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loadout := newBuddyTestLoadout()
+			skipped := applyLoadoutChanges(loadout, &models.LoadoutUpdateRequest{Guns: tt.changes}, owned, catalog)
+
+			if len(skipped) != tt.wantSkipped {
+				t.Errorf("跳过的物品数量为%d，期望%d: %+v", len(skipped), tt.wantSkipped, skipped)
+			}
+			if got := loadout.Guns[0].CharmInstanceID; got != tt.wantA {
+				t.Errorf("gun-a的枪饰实例为%q，期望%q", got, tt.wantA)
+			}
+			if got := loadout.Guns[1].CharmInstanceID; got != tt.wantB {
+				t.Errorf("gun-b的枪饰实例为%q，期望%q", got, tt.wantB)
+			}
+		})
+	}
+}
@@ -0,0 +1,365 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/emper0r/val-store-server/internal/models"
+	"github.com/emper0r/val-store-server/internal/repositories"
+)
+
+const (
+	// archiveSyncPageSize 同步比赛存档时每次请求的比赛历史数量，Riot限制为20
+	archiveSyncPageSize = 20
+
+	// archiveStatsVersion 存档索引中统计数据的计算版本，修改计算方式时递增
+	archiveStatsVersion = 1
+)
+
+// ErrInvalidArchiveQuery 存档查询的筛选条件无效
+var ErrInvalidArchiveQuery = errors.New("无效的筛选条件")
+
+// archiveFilter 解析后的存档筛选条件，所有ID都是小写
+type archiveFilter struct {
+	mapIDs   map[string]bool // 同一地图的UUID和mapId
+	agentID  string
+	seasonID string
+	queue    string
+}
+
+// matches 判断存档比赛是否满足筛选条件
+func (f *archiveFilter) matches(match models.ArchivedMatch) bool {
+	if f.mapIDs != nil && !f.mapIDs[strings.ToLower(match.MapID)] {
+		return false
+	}
+	if f.agentID != "" && !strings.EqualFold(match.AgentID, f.agentID) {
+		return false
+	}
+	if f.seasonID != "" && !strings.EqualFold(match.SeasonID, f.seasonID) {
+		return false
+	}
+	if f.queue != "" && !strings.EqualFold(match.QueueID, f.queue) {
+		return false
+	}
+	return true
+}
+
+// resolveArchiveFilter 将查询中的名称解析为ID，内容目录不可用时按原始值比较
+func resolveArchiveFilter(query *models.MatchArchiveQuery, catalog *repositories.Catalog) (*archiveFilter, error) {
+	filter := &archiveFilter{
+		agentID:  query.Agent,
+		seasonID: query.Act,
+		queue:    query.Queue,
+	}
+
+	if query.Map != "" {
+		filter.mapIDs = map[string]bool{strings.ToLower(query.Map): true}
+		if catalog != nil {
+			m := catalog.FindMap(query.Map)
+			if m == nil {
+				return nil, fmt.Errorf("%w: 未知的地图 %s", ErrInvalidArchiveQuery, query.Map)
+			}
+			filter.mapIDs[strings.ToLower(m.UUID)] = true
+			filter.mapIDs[strings.ToLower(m.MapURL)] = true
+		}
+	}
+
+	if query.Agent != "" && catalog != nil {
+		agent := catalog.FindAgent(query.Agent)
+		if agent == nil {
+			return nil, fmt.Errorf("%w: 未知的特工 %s", ErrInvalidArchiveQuery, query.Agent)
+		}
+		filter.agentID = agent.UUID
+	}
+
+	if strings.EqualFold(query.Act, "current") {
+		act := catalog.CurrentAct(time.Now())
+		if act == nil {
+			return nil, fmt.Errorf("%w: 无法确定当前小赛季", ErrInvalidArchiveQuery)
+		}
+		filter.seasonID = act.UUID
+	}
+
+	return filter, nil
+}
+
+// filterArchive 返回满足筛选条件的存档比赛
+func filterArchive(index []models.ArchivedMatch, filter *archiveFilter) []models.ArchivedMatch {
+	var matches []models.ArchivedMatch
+	for _, match := range index {
+		if filter.matches(match) {
+			matches = append(matches, match)
+		}
+	}
+	return matches
+}
+
+// newArchivedMatch 从比赛详情中提取玩家的存档索引
+func newArchivedMatch(details *models.ValorantMatchDetails, puuid string) models.ArchivedMatch {
+	info := details.MatchInfo
+	entry := models.ArchivedMatch{
+		MatchID:   info.MatchID,
+		StartTime: time.UnixMilli(info.GameStartMillis).UTC(),
+		QueueID:   info.QueueID,
+		MapID:     info.MapID,
+		SeasonID:  info.SeasonID,
+	}
+	entry.Result, entry.RoundsWon, entry.RoundsLost = matchResult(details, puuid)
+
+	for _, player := range details.Players {
+		if strings.EqualFold(player.Subject, puuid) {
+			entry.AgentID = player.CharacterID
+			entry.RoundsPlayed = player.Stats.RoundsPlayed
+			entry.Kills = player.Stats.Kills
+			entry.Deaths = player.Stats.Deaths
+			entry.Assists = player.Stats.Assists
+			entry.Score = player.Stats.Score
+			break
+		}
+	}
+
+	collectArchiveStats(&entry, details, puuid)
+	return entry
+}
+
+// collectArchiveStats 计算玩家的命中部位、残局和各武器击杀数
+func collectArchiveStats(entry *models.ArchivedMatch, details *models.ValorantMatchDetails, puuid string) {
+	entry.StatsVersion = archiveStatsVersion
+	entry.WeaponKills = make(map[string]int)
+
+	teams := make(map[string]string, len(details.Players)) // 玩家ID -> 队伍ID
+	for _, player := range details.Players {
+		teams[strings.ToLower(player.Subject)] = player.TeamID
+	}
+	self := strings.ToLower(puuid)
+	ownTeam, inMatch := teams[self]
+	// 死斗等没有两支队伍的模式不计算残局
+	teamMode := len(details.Teams) == 2
+
+	for _, round := range details.RoundResults {
+		var kills []models.ValorantKill
+		for _, playerStats := range round.PlayerStats {
+			kills = append(kills, playerStats.Kills...)
+			if !strings.EqualFold(playerStats.Subject, puuid) {
+				continue
+			}
+			for _, damage := range playerStats.Damage {
+				entry.Headshots += damage.Headshots
+				entry.Bodyshots += damage.Bodyshots
+				entry.Legshots += damage.Legshots
+			}
+		}
+		sort.SliceStable(kills, func(i, j int) bool {
+			return kills[i].RoundTime < kills[j].RoundTime
+		})
+
+		for _, kill := range kills {
+			if !strings.EqualFold(kill.Killer, puuid) {
+				continue
+			}
+			key := kill.FinishingDamage.DamageType
+			if key == "Weapon" && kill.FinishingDamage.DamageItem != "" {
+				key = strings.ToLower(kill.FinishingDamage.DamageItem)
+			}
+			if key == "" {
+				key = "Unknown"
+			}
+			entry.WeaponKills[key]++
+		}
+
+		if !teamMode || !inMatch {
+			continue
+		}
+
+		// 按击杀顺序模拟存活人数，玩家成为队伍最后一人且仍有敌人存活时进入残局
+		alive := make(map[string]bool, len(teams))
+		for subject := range teams {
+			alive[subject] = true
+		}
+		clutch := false
+		for _, kill := range kills {
+			delete(alive, strings.ToLower(kill.Victim))
+			if clutch || !alive[self] {
+				continue
+			}
+
+			allies, enemies := 0, 0
+			for subject := range alive {
+				if teams[subject] == ownTeam {
+					allies++
+				} else {
+					enemies++
+				}
+			}
+			if allies == 1 && enemies > 0 {
+				clutch = true
+			}
+		}
+		if clutch {
+			entry.ClutchAttempts++
+			if round.WinningTeam == ownTeam {
+				entry.Clutches++
+			}
+		}
+	}
+}
+
+// QueryArchive 按地图、特工、小赛季和模式查询存档中的比赛
+func (s *MatchService) QueryArchive(userID string, query *models.MatchArchiveQuery, language string) (*models.MatchArchiveResponse, error) {
+	catalog := loadCatalog(s.contentCatalog, language)
+	filter, err := resolveArchiveFilter(query, catalog)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := s.archive.Index(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := filterArchive(index, filter)
+	if query.Limit > 0 && len(matches) > query.Limit {
+		matches = matches[:query.Limit]
+	}
+
+	response := &models.MatchArchiveResponse{
+		Count:   len(matches),
+		Matches: make([]models.ArchivedMatchEntry, 0, len(matches)),
+	}
+	for _, match := range matches {
+		agent, _ := catalog.ResolveItem(match.AgentID)
+		entry := models.ArchivedMatchEntry{
+			MatchID:    match.MatchID,
+			QueueID:    match.QueueID,
+			QueueName:  catalog.QueueName(match.QueueID),
+			Map:        catalog.ResolveMap(match.MapID),
+			AgentID:    match.AgentID,
+			Agent:      agent,
+			SeasonID:   match.SeasonID,
+			SeasonName: catalog.SeasonName(match.SeasonID),
+			StartTime:  match.StartTime,
+			Result:     match.Result,
+			RoundsWon:  match.RoundsWon,
+			RoundsLost: match.RoundsLost,
+			Kills:      match.Kills,
+			Deaths:     match.Deaths,
+			Assists:    match.Assists,
+		}
+		if match.RoundsPlayed > 0 {
+			entry.ACS = match.Score / match.RoundsPlayed
+		}
+		response.Matches = append(response.Matches, entry)
+	}
+
+	return response, nil
+}
+
+// SyncMatches 将玩家最近的比赛保存到存档，只获取比存档中最新的比赛更新的比赛
+// 返回新保存的比赛数量和存档中的比赛总数
+func (s *MatchService) SyncMatches(session *models.UserSession) (int, int, error) {
+	unlock := s.syncLocks.lock(session.UserID)
+	defer unlock()
+
+	index, err := s.archive.Index(session.UserID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	known := make(map[string]bool, len(index))
+	for _, match := range index {
+		known[match.MatchID] = true
+	}
+	var latest time.Time
+	if len(index) > 0 {
+		latest = index[0].StartTime
+	}
+
+	client, err := s.valorantAPI.NewClient(session)
+	if err != nil {
+		return 0, len(index), err
+	}
+
+	// 比赛历史从最近的比赛开始，遇到已存档的比赛就停止翻页
+	var newMatchIDs []string
+	for start := 0; ; start += archiveSyncPageSize {
+		history, err := client.GetMatchHistory(start, start+archiveSyncPageSize, "")
+		if err != nil {
+			return 0, len(index), err
+		}
+
+		reachedArchived := false
+		for _, match := range history.History {
+			if known[match.MatchID] || !time.UnixMilli(match.GameStartTime).After(latest) {
+				reachedArchived = true
+				break
+			}
+			newMatchIDs = append(newMatchIDs, match.MatchID)
+		}
+
+		if reachedArchived || len(history.History) == 0 || start+len(history.History) >= history.Total {
+			break
+		}
+	}
+
+	// 从最早的比赛开始保存，中途失败时下次同步仍能补上更新的比赛
+	saved := 0
+	for i := len(newMatchIDs) - 1; i >= 0; i-- {
+		details, err := client.GetMatchDetails(newMatchIDs[i])
+		if err != nil {
+			return saved, len(index) + saved, err
+		}
+		if err := s.archive.Save(session.UserID, newArchivedMatch(details, session.UserID), details); err != nil {
+			return saved, len(index) + saved, err
+		}
+		saved++
+	}
+
+	return saved, len(index) + saved, nil
+}
+
+// SyncAllMatches 为所有已登录的用户同步比赛存档，同一用户有多个会话时只同步一次
+func (s *MatchService) SyncAllMatches() {
+	sessions, err := s.sessionStore.All()
+	if err != nil {
+		log.Printf("读取会话列表失败: %v", err)
+		return
+	}
+
+	now := time.Now()
+	synced := make(map[string]bool)
+	for _, session := range sessions {
+		if session.Dead || !session.TokenExpiresAt.After(now) || synced[session.UserID] {
+			continue
+		}
+		synced[session.UserID] = true
+
+		saved, total, err := s.SyncMatches(session)
+		if err != nil {
+			log.Printf("用户 %s 的比赛存档同步失败: %v", session.UserID, err)
+			continue
+		}
+		if saved > 0 {
+			log.Printf("用户 %s 新存档了 %d 场比赛，共 %d 场", session.UserID, saved, total)
+		}
+	}
+}
+
+// StartMatchSync 在后台定期同步比赛存档，直到ctx被取消
+func (s *MatchService) StartMatchSync(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.SyncAllMatches()
+		}
+	}
+}
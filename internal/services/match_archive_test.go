@@ -0,0 +1,124 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/emper0r/val-store-server/internal/models"
+)
+
+func TestNewArchivedMatch(t *testing.T) {
+	details := loadTestMatch(t)
+
+	tests := []struct {
+		puuid       string
+		wantAgent   string
+		wantKills   int
+		wantDeaths  int
+		wantAssists int
+		wantScore   int
+	}{
+		{puuid: "a1", wantAgent: "agent-1", wantKills: 2, wantDeaths: 1, wantScore: 400},
+		{puuid: "A2", wantAgent: "agent-2", wantKills: 1, wantDeaths: 2, wantAssists: 1, wantScore: 200},
+		{puuid: "b2", wantAgent: "agent-4", wantKills: 2, wantDeaths: 1, wantScore: 500},
+		// 玩家不在比赛中时只保留比赛信息
+		{puuid: "x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.puuid, func(t *testing.T) {
+			entry := newArchivedMatch(details, tt.puuid)
+
+			if entry.MatchID != "match-1" || entry.QueueID != "competitive" || entry.MapID != "/Game/Maps/Ascent/Ascent" ||
+				!entry.StartTime.Equal(time.UnixMilli(1700000000000)) {
+				t.Errorf("比赛信息不正确: %+v", entry)
+			}
+			if entry.AgentID != tt.wantAgent || entry.Kills != tt.wantKills || entry.Deaths != tt.wantDeaths ||
+				entry.Assists != tt.wantAssists || entry.Score != tt.wantScore {
+				t.Errorf("玩家数据不正确: %+v", entry)
+			}
+			if tt.wantAgent != "" && (entry.Result != models.MatchResultDraw || entry.RoundsWon != 1 || entry.RoundsLost != 1 || entry.RoundsPlayed != 2) {
+				t.Errorf("比赛结果不正确: %+v", entry)
+			}
+		})
+	}
+}
+
+func TestNewArchivedMatchStats(t *testing.T) {
+	details := loadTestMatch(t)
+
+	tests := []struct {
+		puuid              string
+		wantHeadshots      int
+		wantBodyshots      int
+		wantLegshots       int
+		wantClutchAttempts int
+		wantClutches       int
+		wantWeaponKills    map[string]int
+	}{
+		// 第1回合队友阵亡后1对1获胜
+		{puuid: "a1", wantHeadshots: 3, wantBodyshots: 1, wantClutchAttempts: 1, wantClutches: 1, wantWeaponKills: map[string]int{"weapon-vandal": 2}},
+		// 第2回合队友阵亡后1对2落败
+		{puuid: "a2", wantClutchAttempts: 1, wantWeaponKills: map[string]int{"Melee": 1}},
+		// 第1回合1对2落败，第2回合1对1获胜
+		{puuid: "b2", wantBodyshots: 1, wantLegshots: 1, wantClutchAttempts: 2, wantClutches: 1, wantWeaponKills: map[string]int{"Ability": 1, "weapon-phantom": 1}},
+		{puuid: "b1", wantWeaponKills: map[string]int{"weapon-phantom": 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.puuid, func(t *testing.T) {
+			entry := newArchivedMatch(details, tt.puuid)
+
+			if entry.MatchID != "match-1" || entry.Result != models.MatchResultDraw || entry.RoundsWon != 1 || entry.RoundsLost != 1 {
+				t.Errorf("比赛结果不正确: %+v", entry)
+			}
+			if entry.StatsVersion != archiveStatsVersion {
+				t.Errorf("统计版本为%d，期望%d", entry.StatsVersion, archiveStatsVersion)
+			}
+			if entry.Headshots != tt.wantHeadshots || entry.Bodyshots != tt.wantBodyshots || entry.Legshots != tt.wantLegshots {
+				t.Errorf("命中部位为(%d, %d, %d)，期望(%d, %d, %d)",
+					entry.Headshots, entry.Bodyshots, entry.Legshots, tt.wantHeadshots, tt.wantBodyshots, tt.wantLegshots)
+			}
+			if entry.ClutchAttempts != tt.wantClutchAttempts || entry.Clutches != tt.wantClutches {
+				t.Errorf("残局为%d/%d，期望%d/%d", entry.Clutches, entry.ClutchAttempts, tt.wantClutches, tt.wantClutchAttempts)
+			}
+			if fmt.Sprint(entry.WeaponKills) != fmt.Sprint(tt.wantWeaponKills) {
+				t.Errorf("武器击杀为%v，期望%v", entry.WeaponKills, tt.wantWeaponKills)
+			}
+		})
+	}
+}
+
+func TestCollectArchiveStatsSkipsClutchesWithoutTwoTeams(t *testing.T) {
+	tests := []struct {
+		name    string
+		details func() *models.ValorantMatchDetails
+		puuid   string
+	}{
+		{
+			name: "死斗",
+			details: func() *models.ValorantMatchDetails {
+				details := loadTestMatch(t)
+				details.Teams = append(details.Teams, models.ValorantMatchTeam{TeamID: "Green"})
+				return details
+			},
+			puuid: "a1",
+		},
+		{
+			name:    "玩家不在比赛中",
+			details: func() *models.ValorantMatchDetails { return loadTestMatch(t) },
+			puuid:   "x",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var entry models.ArchivedMatch
+			collectArchiveStats(&entry, tt.details(), tt.puuid)
+			if entry.ClutchAttempts != 0 || entry.Clutches != 0 {
+				t.Errorf("不应计算残局: %+v", entry)
+			}
+		})
+	}
+}
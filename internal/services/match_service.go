@@ -0,0 +1,286 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/emper0r/val-store-server/internal/models"
+	"github.com/emper0r/val-store-server/internal/repositories"
+)
+
+// 比赛历史的分页限制
+const (
+	DefaultMatchPageSize = 10
+	MaxMatchPageSize     = 20
+
+	// matchDetailsConcurrency 获取一页比赛详情时的最大并发请求数
+	matchDetailsConcurrency = 4
+)
+
+// ErrInvalidMatchRange 比赛历史的分页参数无效
+var ErrInvalidMatchRange = errors.New("无效的分页参数")
+
+// MatchService 处理比赛历史和比赛详情相关的业务逻辑
+type MatchService struct {
+	valorantAPI    *repositories.ValorantAPI
+	contentCatalog *repositories.ContentCatalog
+	sessionStore   repositories.SessionStore
+	archive        repositories.MatchArchive
+	syncLocks      sessionLocks // 按用户ID加锁，避免同一用户被并发同步
+}
+
+// NewMatchService 创建新的比赛服务
+func NewMatchService(valorantAPI *repositories.ValorantAPI, contentCatalog *repositories.ContentCatalog, sessionStore repositories.SessionStore, archive repositories.MatchArchive) *MatchService {
+	return &MatchService{
+		valorantAPI:    valorantAPI,
+		contentCatalog: contentCatalog,
+		sessionStore:   sessionStore,
+		archive:        archive,
+	}
+}
+
+// GetMatchHistory 获取一页比赛历史，start和end是从最近一场比赛开始的下标（不包含end）
+// 历史接口只返回比赛ID，地图和胜负需要逐场获取比赛详情；单场详情获取失败时只返回基本信息
+func (s *MatchService) GetMatchHistory(session *models.UserSession, queue string, start, end int, language string) (*models.MatchHistoryResponse, error) {
+	if start < 0 || end <= start || end-start > MaxMatchPageSize {
+		return nil, fmt.Errorf("%w: start不能小于0，end必须大于start且每页最多%d场", ErrInvalidMatchRange, MaxMatchPageSize)
+	}
+
+	client, err := s.valorantAPI.NewClient(session)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := client.GetMatchHistory(start, end, queue)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := loadCatalog(s.contentCatalog, language)
+	response := &models.MatchHistoryResponse{
+		Total:   history.Total,
+		Start:   start,
+		End:     start + len(history.History),
+		Matches: make([]models.MatchHistoryEntry, len(history.History)),
+	}
+	if response.End < history.Total && len(history.History) > 0 {
+		next := response.End
+		response.NextStart = &next
+	}
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, matchDetailsConcurrency)
+	for i, match := range history.History {
+		response.Matches[i] = models.MatchHistoryEntry{
+			MatchID:   match.MatchID,
+			QueueID:   match.QueueID,
+			QueueName: catalog.QueueName(match.QueueID),
+			StartTime: time.UnixMilli(match.GameStartTime).UTC(),
+		}
+
+		wg.Add(1)
+		go func(entry *models.MatchHistoryEntry) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			details, err := client.GetMatchDetails(entry.MatchID)
+			if err != nil {
+				log.Printf("警告: 获取比赛 %s 的详情失败: %v", entry.MatchID, err)
+				return
+			}
+
+			mapInfo := catalog.ResolveMap(details.MatchInfo.MapID)
+			entry.Map = &mapInfo
+			entry.Result, entry.RoundsWon, entry.RoundsLost = matchResult(details, client.PUUID())
+		}(&response.Matches[i])
+	}
+	wg.Wait()
+
+	return response, nil
+}
+
+// matchResult 计算玩家在比赛中的胜负和比分
+// 死斗模式中每个玩家是一个队伍，比分为玩家和最高的其他玩家的得分
+func matchResult(details *models.ValorantMatchDetails, puuid string) (string, int, int) {
+	teamID := ""
+	for _, player := range details.Players {
+		if strings.EqualFold(player.Subject, puuid) {
+			teamID = player.TeamID
+			break
+		}
+	}
+
+	var own *models.ValorantMatchTeam
+	lost := 0
+	for i := range details.Teams {
+		team := &details.Teams[i]
+		if team.TeamID == teamID {
+			own = team
+		} else if team.RoundsWon > lost {
+			lost = team.RoundsWon
+		}
+	}
+	if own == nil {
+		return "", 0, 0
+	}
+
+	anyWon := false
+	for _, team := range details.Teams {
+		anyWon = anyWon || team.Won
+	}
+
+	switch {
+	case own.Won:
+		return models.MatchResultVictory, own.RoundsWon, lost
+	case !anyWon:
+		return models.MatchResultDraw, own.RoundsWon, lost
+	default:
+		return models.MatchResultDefeat, own.RoundsWon, lost
+	}
+}
+
+// GetMatchSummary 获取比赛详情并精简为每个玩家、队伍和回合的摘要
+// 优先读取存档，Riot只保留最近的比赛，存档中的比赛可以一直查询
+func (s *MatchService) GetMatchSummary(session *models.UserSession, matchID, language string) (*models.MatchSummary, error) {
+	details, err := s.archive.Details(session.UserID, matchID)
+	if err != nil {
+		if !errors.Is(err, repositories.ErrMatchNotArchived) {
+			log.Printf("警告: 读取存档比赛 %s 失败: %v", matchID, err)
+		}
+
+		client, err := s.valorantAPI.NewClient(session)
+		if err != nil {
+			return nil, err
+		}
+
+		details, err = client.GetMatchDetails(matchID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return summarizeMatch(details, loadCatalog(s.contentCatalog, language)), nil
+}
+
+// playerAccumulator 汇总玩家在各回合中的数据
+type playerAccumulator struct {
+	headshots, bodyshots, legshots int
+	damage                         int
+	firstBloods                    int
+	loadoutValue, spent            int
+}
+
+// summarizeMatch 将Riot的比赛详情转换为摘要
+func summarizeMatch(details *models.ValorantMatchDetails, catalog *repositories.Catalog) *models.MatchSummary {
+	info := details.MatchInfo
+	summary := &models.MatchSummary{
+		MatchID:         info.MatchID,
+		Map:             catalog.ResolveMap(info.MapID),
+		QueueID:         info.QueueID,
+		QueueName:       catalog.QueueName(info.QueueID),
+		SeasonID:        info.SeasonID,
+		StartTime:       time.UnixMilli(info.GameStartMillis).UTC(),
+		DurationSeconds: info.GameLengthMillis / 1000,
+		Completed:       info.IsCompleted,
+		Teams:           make([]models.TeamMatchSummary, 0, len(details.Teams)),
+		Players:         make([]models.PlayerMatchSummary, 0, len(details.Players)),
+		Rounds:          make([]models.RoundSummary, 0, len(details.RoundResults)),
+	}
+
+	for _, team := range details.Teams {
+		summary.Teams = append(summary.Teams, models.TeamMatchSummary{
+			TeamID:    team.TeamID,
+			Won:       team.Won,
+			RoundsWon: team.RoundsWon,
+		})
+	}
+
+	stats := make(map[string]*playerAccumulator, len(details.Players))
+	accumulator := func(subject string) *playerAccumulator {
+		acc, ok := stats[subject]
+		if !ok {
+			acc = &playerAccumulator{}
+			stats[subject] = acc
+		}
+		return acc
+	}
+
+	for _, round := range details.RoundResults {
+		summary.Rounds = append(summary.Rounds, models.RoundSummary{
+			Round:       round.RoundNum + 1,
+			WinningTeam: round.WinningTeam,
+			Result:      round.RoundResultCode,
+			PlantSite:   round.PlantSite,
+		})
+
+		// 回合中最早的击杀为首杀
+		var firstKill *models.ValorantKill
+		for i := range round.PlayerStats {
+			playerStats := &round.PlayerStats[i]
+			acc := accumulator(playerStats.Subject)
+			acc.loadoutValue += playerStats.Economy.LoadoutValue
+			acc.spent += playerStats.Economy.Spent
+			for _, damage := range playerStats.Damage {
+				acc.damage += damage.Damage
+				acc.headshots += damage.Headshots
+				acc.bodyshots += damage.Bodyshots
+				acc.legshots += damage.Legshots
+			}
+			for j := range playerStats.Kills {
+				kill := &playerStats.Kills[j]
+				if firstKill == nil || kill.RoundTime < firstKill.RoundTime {
+					firstKill = kill
+				}
+			}
+		}
+		if firstKill != nil {
+			accumulator(firstKill.Killer).firstBloods++
+		}
+	}
+
+	for _, player := range details.Players {
+		acc := accumulator(player.Subject)
+		agent, _ := catalog.ResolveItem(player.CharacterID)
+		playerSummary := models.PlayerMatchSummary{
+			Subject:     player.Subject,
+			Name:        player.GameName + "#" + player.TagLine,
+			TeamID:      player.TeamID,
+			AgentID:     player.CharacterID,
+			Agent:       agent,
+			Kills:       player.Stats.Kills,
+			Deaths:      player.Stats.Deaths,
+			Assists:     player.Stats.Assists,
+			FirstBloods: acc.firstBloods,
+			Damage:      acc.damage,
+			Economy:     models.PlayerEconomy{TotalSpent: acc.spent},
+		}
+
+		if rounds := player.Stats.RoundsPlayed; rounds > 0 {
+			playerSummary.ACS = player.Stats.Score / rounds
+			playerSummary.ADR = acc.damage / rounds
+			playerSummary.Economy.AvgLoadoutValue = acc.loadoutValue / rounds
+			playerSummary.Economy.AvgSpent = acc.spent / rounds
+		}
+		if shots := acc.headshots + acc.bodyshots + acc.legshots; shots > 0 {
+			playerSummary.HeadshotPercent = math.Round(float64(acc.headshots)*1000/float64(shots)) / 10
+		}
+
+		summary.Players = append(summary.Players, playerSummary)
+	}
+
+	sort.SliceStable(summary.Players, func(i, j int) bool {
+		if summary.Players[i].TeamID != summary.Players[j].TeamID {
+			return summary.Players[i].TeamID < summary.Players[j].TeamID
+		}
+		return summary.Players[i].ACS > summary.Players[j].ACS
+	})
+
+	return summary
+}
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/emper0r/val-store-server/internal/models"
+	"github.com/emper0r/val-store-server/internal/repositories"
+)
+
+// testMatchJSON 2对2的比赛，每队各赢一回合
+// 第1回合：a1首杀b1，b2用技能击杀a2，a1在1对1残局中击杀b2，红队获胜
+// 第2回合：b1首杀a1，a2击杀b1，b2在1对1残局中击杀a2，蓝队获胜
+const testMatchJSON = `{
+	"matchInfo": {"matchId": "match-1", "mapId": "/Game/Maps/Ascent/Ascent", "queueID": "competitive", "gameStartMillis": 1700000000000, "gameLengthMillis": 1800000, "isCompleted": true},
+	"players": [
+		{"subject": "a1", "gameName": "A1", "tagLine": "red", "teamId": "Red", "characterId": "agent-1", "stats": {"score": 400, "roundsPlayed": 2, "kills": 2, "deaths": 1, "assists": 0}},
+		{"subject": "a2", "gameName": "A2", "tagLine": "red", "teamId": "Red", "characterId": "agent-2", "stats": {"score": 200, "roundsPlayed": 2, "kills": 1, "deaths": 2, "assists": 1}},
+		{"subject": "b1", "gameName": "B1", "tagLine": "blue", "teamId": "Blue", "characterId": "agent-3", "stats": {"score": 300, "roundsPlayed": 2, "kills": 1, "deaths": 2, "assists": 0}},
+		{"subject": "b2", "gameName": "B2", "tagLine": "blue", "teamId": "Blue", "characterId": "agent-4", "stats": {"score": 500, "roundsPlayed": 2, "kills": 2, "deaths": 1, "assists": 0}}
+	],
+	"teams": [
+		{"teamId": "Red", "won": false, "roundsPlayed": 2, "roundsWon": 1},
+		{"teamId": "Blue", "won": false, "roundsPlayed": 2, "roundsWon": 1}
+	],
+	"roundResults": [
+		{
+			"roundNum": 0, "roundResultCode": "Elimination", "winningTeam": "Red",
+			"playerStats": [
+				{
+					"subject": "a1",
+					"kills": [
+						{"roundTime": 1000, "killer": "a1", "victim": "b1", "finishingDamage": {"damageType": "Weapon", "damageItem": "WEAPON-VANDAL"}},
+						{"roundTime": 3000, "killer": "a1", "victim": "b2", "finishingDamage": {"damageType": "Weapon", "damageItem": "WEAPON-VANDAL"}}
+					],
+					"damage": [
+						{"receiver": "b1", "damage": 150, "headshots": 1, "bodyshots": 1},
+						{"receiver": "b2", "damage": 150, "headshots": 2}
+					],
+					"economy": {"loadoutValue": 3900, "spent": 3000}
+				},
+				{
+					"subject": "b2",
+					"kills": [
+						{"roundTime": 2000, "killer": "b2", "victim": "a2", "finishingDamage": {"damageType": "Ability", "damageItem": "Ultimate"}}
+					],
+					"damage": [{"receiver": "a2", "damage": 100, "bodyshots": 1, "legshots": 1}]
+				}
+			]
+		},
+		{
+			"roundNum": 1, "roundResultCode": "Elimination", "winningTeam": "Blue", "plantSite": "A",
+			"playerStats": [
+				{
+					"subject": "a1",
+					"damage": [],
+					"economy": {"loadoutValue": 800, "spent": 400}
+				},
+				{
+					"subject": "b1",
+					"kills": [
+						{"roundTime": 500, "killer": "b1", "victim": "a1", "finishingDamage": {"damageType": "Weapon", "damageItem": "WEAPON-PHANTOM"}}
+					]
+				},
+				{
+					"subject": "a2",
+					"kills": [
+						{"roundTime": 900, "killer": "a2", "victim": "b1", "finishingDamage": {"damageType": "Melee"}}
+					]
+				},
+				{
+					"subject": "b2",
+					"kills": [
+						{"roundTime": 1500, "killer": "b2", "victim": "a2", "finishingDamage": {"damageType": "Weapon", "damageItem": "WEAPON-PHANTOM"}}
+					]
+				}
+			]
+		}
+	]
+}`
+
+// loadTestMatch 解析测试用的比赛详情
+func loadTestMatch(t *testing.T) *models.ValorantMatchDetails {
+	t.Helper()
+
+	var details models.ValorantMatchDetails
+	if err := json.Unmarshal([]byte(testMatchJSON), &details); err != nil {
+		t.Fatal(err)
+	}
+	return &details
+}
+
+func TestMatchResult(t *testing.T) {
+	twoTeams := func(redWon, blueWon bool, redRounds, blueRounds int) *models.ValorantMatchDetails {
+		details := &models.ValorantMatchDetails{
+			Players: []models.ValorantMatchPlayer{{Subject: "a1", TeamID: "Red"}, {Subject: "b1", TeamID: "Blue"}},
+			Teams: []models.ValorantMatchTeam{
+				{TeamID: "Red", Won: redWon, RoundsWon: redRounds},
+				{TeamID: "Blue", Won: blueWon, RoundsWon: blueRounds},
+			},
+		}
+		return details
+	}
+	deathmatch := &models.ValorantMatchDetails{
+		Players: []models.ValorantMatchPlayer{{Subject: "a1", TeamID: "a1"}, {Subject: "b1", TeamID: "b1"}, {Subject: "c1", TeamID: "c1"}},
+		Teams: []models.ValorantMatchTeam{
+			{TeamID: "a1", Won: false, RoundsWon: 30},
+			{TeamID: "b1", Won: true, RoundsWon: 40},
+			{TeamID: "c1", Won: false, RoundsWon: 10},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		details    *models.ValorantMatchDetails
+		puuid      string
+		wantResult string
+		wantWon    int
+		wantLost   int
+	}{
+		{name: "胜利", details: twoTeams(true, false, 13, 7), puuid: "a1", wantResult: models.MatchResultVictory, wantWon: 13, wantLost: 7},
+		{name: "失败", details: twoTeams(true, false, 13, 7), puuid: "b1", wantResult: models.MatchResultDefeat, wantWon: 7, wantLost: 13},
+		{name: "平局", details: twoTeams(false, false, 12, 12), puuid: "a1", wantResult: models.MatchResultDraw, wantWon: 12, wantLost: 12},
+		{name: "玩家ID忽略大小写", details: twoTeams(true, false, 13, 11), puuid: "A1", wantResult: models.MatchResultVictory, wantWon: 13, wantLost: 11},
+		{name: "死斗取最高的对手分数", details: deathmatch, puuid: "a1", wantResult: models.MatchResultDefeat, wantWon: 30, wantLost: 40},
+		{name: "玩家不在比赛中", details: twoTeams(true, false, 13, 7), puuid: "x", wantResult: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, won, lost := matchResult(tt.details, tt.puuid)
+			if result != tt.wantResult || won != tt.wantWon || lost != tt.wantLost {
+				t.Errorf("matchResult() = (%q, %d, %d)，期望(%q, %d, %d)", result, won, lost, tt.wantResult, tt.wantWon, tt.wantLost)
+			}
+		})
+	}
+}
+
+func TestSummarizeMatch(t *testing.T) {
+	summary := summarizeMatch(loadTestMatch(t), nil)
+
+	if summary.MatchID != "match-1" || summary.DurationSeconds != 1800 || !summary.Completed {
+		t.Errorf("比赛信息不正确: %+v", summary)
+	}
+	if len(summary.Rounds) != 2 || summary.Rounds[0].Round != 1 || summary.Rounds[1].WinningTeam != "Blue" || summary.Rounds[1].PlantSite != "A" {
+		t.Errorf("回合摘要不正确: %+v", summary.Rounds)
+	}
+
+	// 玩家按队伍排序，队伍内按ACS降序
+	order := make([]string, 0, len(summary.Players))
+	for _, player := range summary.Players {
+		order = append(order, player.Subject)
+	}
+	if want := []string{"b2", "b1", "a1", "a2"}; fmt.Sprint(order) != fmt.Sprint(want) {
+		t.Errorf("玩家顺序为%v，期望%v", order, want)
+	}
+
+	players := make(map[string]models.PlayerMatchSummary, len(summary.Players))
+	for _, player := range summary.Players {
+		players[player.Subject] = player
+	}
+
+	tests := []struct {
+		subject         string
+		wantACS         int
+		wantADR         int
+		wantDamage      int
+		wantHeadshot    float64
+		wantFirstBloods int
+		wantEconomy     models.PlayerEconomy
+	}{
+		{subject: "a1", wantACS: 200, wantADR: 150, wantDamage: 300, wantHeadshot: 75, wantFirstBloods: 1, wantEconomy: models.PlayerEconomy{AvgLoadoutValue: 2350, AvgSpent: 1700, TotalSpent: 3400}},
+		{subject: "b1", wantACS: 150, wantFirstBloods: 1},
+		{subject: "b2", wantACS: 250, wantADR: 50, wantDamage: 100},
+		{subject: "a2", wantACS: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subject, func(t *testing.T) {
+			player, ok := players[tt.subject]
+			if !ok {
+				t.Fatalf("摘要中没有玩家%s", tt.subject)
+			}
+			if player.ACS != tt.wantACS || player.ADR != tt.wantADR || player.Damage != tt.wantDamage ||
+				player.HeadshotPercent != tt.wantHeadshot || player.FirstBloods != tt.wantFirstBloods || player.Economy != tt.wantEconomy {
+				t.Errorf("玩家数据不正确: %+v", player)
+			}
+		})
+	}
+
+	if players["a1"].Name != "A1#red" || players["a1"].Kills != 2 || players["a1"].Deaths != 1 {
+		t.Errorf("玩家基本信息不正确: %+v", players["a1"])
+	}
+}
+
+func TestSummarizeMatchResolvesContent(t *testing.T) {
+	catalog := &repositories.Catalog{
+		Maps: map[string]*models.ContentMap{
+			"/game/maps/ascent/ascent": {UUID: "map-ascent", DisplayName: "亚海悬城", ListViewIcon: "ascent.png"},
+		},
+		Queues: map[string]*models.ContentQueue{
+			"competitive": {QueueID: "competitive", DisplayName: "竞技模式"},
+		},
+		Agents: map[string]*models.ContentAgent{
+			"agent-1": {UUID: "agent-1", DisplayName: "捷风"},
+		},
+	}
+	details := loadTestMatch(t)
+	// 中途加入且没有完整回合的玩家不计算场均数据
+	details.Players = append(details.Players, models.ValorantMatchPlayer{Subject: "c1", TeamID: "Red", CharacterID: "agent-x"})
+
+	summary := summarizeMatch(details, catalog)
+
+	if summary.Map.Name != "亚海悬城" || summary.Map.Icon != "ascent.png" || summary.QueueName != "竞技模式" {
+		t.Errorf("地图或模式名称不正确: %+v %q", summary.Map, summary.QueueName)
+	}
+	if len(summary.Teams) != 2 || summary.Teams[0].TeamID != "Red" || summary.Teams[0].RoundsWon != 1 {
+		t.Errorf("队伍摘要不正确: %+v", summary.Teams)
+	}
+
+	players := make(map[string]models.PlayerMatchSummary, len(summary.Players))
+	for _, player := range summary.Players {
+		players[player.Subject] = player
+	}
+	if players["a1"].Agent.Name != "捷风" || players["a2"].Agent.Name != "" {
+		t.Errorf("特工名称不正确: %+v %+v", players["a1"].Agent, players["a2"].Agent)
+	}
+	if c1 := players["c1"]; c1.ACS != 0 || c1.ADR != 0 || c1.HeadshotPercent != 0 || c1.Economy != (models.PlayerEconomy{}) {
+		t.Errorf("没有回合的玩家数据不正确: %+v", c1)
+	}
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"log"
+	"math"
+	"sort"
+
+	"github.com/emper0r/val-store-server/internal/models"
+)
+
+// archivedIndex 返回用户的存档索引，统计数据版本过旧的比赛会从存档的详情中重新计算
+// 重新计算的结果一次性写回索引；详情无法读取的比赛标记为统计不可用，之后不再重试
+func (s *MatchService) archivedIndex(userID string) ([]models.ArchivedMatch, error) {
+	index, err := s.archive.Index(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated []models.ArchivedMatch
+	for i, match := range index {
+		if match.StatsVersion >= archiveStatsVersion {
+			continue
+		}
+
+		details, err := s.archive.Details(userID, match.MatchID)
+		if err != nil {
+			log.Printf("警告: 读取存档比赛 %s 失败，统计数据将不完整: %v", match.MatchID, err)
+			index[i].StatsVersion = archiveStatsVersion
+			index[i].StatsUnavailable = true
+		} else {
+			index[i] = newArchivedMatch(details, userID)
+		}
+		updated = append(updated, index[i])
+	}
+
+	if err := s.archive.UpdateIndex(userID, updated); err != nil {
+		log.Printf("警告: 更新用户 %s 的存档索引失败: %v", userID, err)
+	}
+
+	return index, nil
+}
+
+// GetStats 汇总存档中满足筛选条件的比赛，只读取存档索引，不请求Riot
+func (s *MatchService) GetStats(userID string, query *models.MatchArchiveQuery, language string) (*models.StatsResponse, error) {
+	catalog := loadCatalog(s.contentCatalog, language)
+	filter, err := resolveArchiveFilter(query, catalog)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := s.archivedIndex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := filterArchive(index, filter)
+	if query.Limit > 0 && len(matches) > query.Limit {
+		matches = matches[:query.Limit]
+	}
+
+	response := &models.StatsResponse{
+		Matches:     len(matches),
+		WeaponKills: []models.WeaponKillStat{},
+	}
+
+	var score, rounds, headshots, shots int
+	weaponKills := make(map[string]int)
+	for _, match := range matches {
+		switch match.Result {
+		case models.MatchResultVictory:
+			response.Wins++
+		case models.MatchResultDefeat:
+			response.Losses++
+		case models.MatchResultDraw:
+			response.Draws++
+		}
+
+		response.Kills += match.Kills
+		response.Deaths += match.Deaths
+		response.Assists += match.Assists
+		response.Clutches.Attempts += match.ClutchAttempts
+		response.Clutches.Won += match.Clutches
+		score += match.Score
+		rounds += match.RoundsPlayed
+		headshots += match.Headshots
+		shots += match.Headshots + match.Bodyshots + match.Legshots
+		for weapon, kills := range match.WeaponKills {
+			weaponKills[weapon] += kills
+		}
+	}
+
+	response.WinRate = percent(response.Wins, response.Matches)
+	response.HeadshotPercent = percent(headshots, shots)
+	response.Clutches.WinRate = percent(response.Clutches.Won, response.Clutches.Attempts)
+	if rounds > 0 {
+		response.AvgACS = round(float64(score)/float64(rounds), 1)
+	}
+	if response.Deaths > 0 {
+		response.KD = round(float64(response.Kills)/float64(response.Deaths), 2)
+	} else {
+		response.KD = float64(response.Kills)
+	}
+
+	totalWeaponKills := 0
+	for _, kills := range weaponKills {
+		totalWeaponKills += kills
+	}
+	for weapon, kills := range weaponKills {
+		info, _ := catalog.ResolveItem(weapon)
+		response.WeaponKills = append(response.WeaponKills, models.WeaponKillStat{
+			ItemInfo: info,
+			ID:       weapon,
+			Kills:    kills,
+			Percent:  percent(kills, totalWeaponKills),
+		})
+	}
+	sort.Slice(response.WeaponKills, func(i, j int) bool {
+		if response.WeaponKills[i].Kills != response.WeaponKills[j].Kills {
+			return response.WeaponKills[i].Kills > response.WeaponKills[j].Kills
+		}
+		return response.WeaponKills[i].ID < response.WeaponKills[j].ID
+	})
+
+	return response, nil
+}
+
+// percent 计算百分比并保留一位小数，分母为0时返回0
+func percent(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return round(float64(part)*100/float64(total), 1)
+}
+
+// round 保留指定位数的小数
+func round(value float64, digits int) float64 {
+	scale := math.Pow(10, float64(digits))
+	return math.Round(value*scale) / scale
+}
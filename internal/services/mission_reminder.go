@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/emper0r/val-store-server/internal/models"
+	"github.com/emper0r/val-store-server/internal/repositories"
+)
+
+// MissionReminder 在每周任务刷新前提醒尚未完成任务的用户
+type MissionReminder struct {
+	contractService *ContractService
+	sessionStore    repositories.SessionStore
+	notifier        repositories.Notifier
+	window          time.Duration // 距离刷新多久时开始提醒
+
+	mu       sync.Mutex
+	notified map[string]time.Time // 用户ID -> 已提醒过的刷新时间，每次刷新只提醒一次，刷新后删除
+}
+
+// NewMissionReminder 创建任务提醒
+func NewMissionReminder(contractService *ContractService, sessionStore repositories.SessionStore, notifier repositories.Notifier, window time.Duration) *MissionReminder {
+	return &MissionReminder{
+		contractService: contractService,
+		sessionStore:    sessionStore,
+		notifier:        notifier,
+		window:          window,
+		notified:        make(map[string]time.Time),
+	}
+}
+
+// CheckAll 检查所有已登录用户的每周任务，同一用户有多个会话时只检查一次
+func (r *MissionReminder) CheckAll() {
+	sessions, err := r.sessionStore.All()
+	if err != nil {
+		log.Printf("读取会话列表失败: %v", err)
+		return
+	}
+
+	now := time.Now()
+	r.prune(now)
+
+	checked := make(map[string]bool)
+	for _, session := range sessions {
+		if session.Dead || !session.TokenExpiresAt.After(now) || checked[session.UserID] {
+			continue
+		}
+		checked[session.UserID] = true
+
+		if err := r.check(session, now); err != nil {
+			log.Printf("用户 %s 的任务提醒检查失败: %v", session.UserID, err)
+		}
+	}
+}
+
+// prune 删除刷新时间已过的提醒记录，下一次刷新前会重新提醒
+func (r *MissionReminder) prune(now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for userID, resetsAt := range r.notified {
+		if !resetsAt.After(now) {
+			delete(r.notified, userID)
+		}
+	}
+}
+
+// check 检查单个用户，未完成的每周任务将在window内刷新时发送提醒
+func (r *MissionReminder) check(session *models.UserSession, now time.Time) error {
+	missions, err := r.contractService.GetMissions(session, "")
+	if err != nil {
+		return err
+	}
+	return r.remind(session, missions, now)
+}
+
+// remind 收集window内刷新的未完成每周任务，同一次刷新只提醒一次
+func (r *MissionReminder) remind(session *models.UserSession, missions *models.MissionsResponse, now time.Time) error {
+	reminder := &models.MissionReminder{
+		UserID:   session.UserID,
+		Username: session.Username,
+	}
+	for _, mission := range missions.Weekly {
+		if mission.Complete || mission.ExpiresAt.IsZero() || mission.ExpiresAt.Sub(now) > r.window || !mission.ExpiresAt.After(now) {
+			continue
+		}
+		reminder.Missions = append(reminder.Missions, mission)
+		reminder.RemainingXP += mission.XP
+		if reminder.ResetsAt.IsZero() || mission.ExpiresAt.Before(reminder.ResetsAt) {
+			reminder.ResetsAt = mission.ExpiresAt
+		}
+	}
+	if len(reminder.Missions) == 0 {
+		return nil
+	}
+
+	r.mu.Lock()
+	if r.notified[session.UserID].Equal(reminder.ResetsAt) {
+		r.mu.Unlock()
+		return nil
+	}
+	r.mu.Unlock()
+
+	if err := r.notifier.Notify(reminder); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.notified[session.UserID] = reminder.ResetsAt
+	r.mu.Unlock()
+	return nil
+}
+
+// Start 在后台定期检查，直到ctx被取消
+func (r *MissionReminder) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.CheckAll()
+		}
+	}
+}
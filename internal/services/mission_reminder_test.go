@@ -0,0 +1,165 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/emper0r/val-store-server/internal/models"
+)
+
+// stubNotifier 记录收到的提醒，err不为空时发送失败
+type stubNotifier struct {
+	reminders []*models.MissionReminder
+	err       error
+}
+
+// Notify 记录提醒
+func (n *stubNotifier) Notify(reminder *models.MissionReminder) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.reminders = append(n.reminders, reminder)
+	return nil
+}
+
+func TestMissionReminderRemind(t *testing.T) {
+	now := time.Date(2024, 1, 22, 12, 0, 0, 0, time.UTC)
+	window := 24 * time.Hour
+	session := &models.UserSession{UserID: "user-1", Username: "Player#0001"}
+
+	weekly := func(id string, xp int, complete bool, expiresIn time.Duration) models.Mission {
+		return models.Mission{ID: id, XP: xp, Complete: complete, ExpiresAt: now.Add(expiresIn)}
+	}
+
+	tests := []struct {
+		name       string
+		missions   []models.Mission
+		want       []string
+		wantXP     int
+		wantResets time.Time
+	}{
+		{
+			name: "提醒window内刷新的未完成任务",
+			missions: []models.Mission{
+				weekly("open", 8000, false, 12*time.Hour),
+				weekly("done", 8000, true, 12*time.Hour),
+				weekly("later", 8000, false, 48*time.Hour),
+			},
+			want:       []string{"open"},
+			wantXP:     8000,
+			wantResets: now.Add(12 * time.Hour),
+		},
+		{
+			name: "刚好在window边界",
+			missions: []models.Mission{
+				weekly("edge", 4000, false, window),
+				weekly("outside", 4000, false, window+time.Second),
+			},
+			want:       []string{"edge"},
+			wantXP:     4000,
+			wantResets: now.Add(window),
+		},
+		{
+			name: "刷新时间取最早的任务",
+			missions: []models.Mission{
+				weekly("a", 4000, false, 10*time.Hour),
+				weekly("b", 8000, false, 2*time.Hour),
+			},
+			want:       []string{"a", "b"},
+			wantXP:     12000,
+			wantResets: now.Add(2 * time.Hour),
+		},
+		{
+			name: "跳过已过期和没有过期时间的任务",
+			missions: []models.Mission{
+				weekly("expired", 8000, false, -time.Minute),
+				weekly("now", 8000, false, 0),
+				{ID: "no-expiry", XP: 8000},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifier := &stubNotifier{}
+			reminder := NewMissionReminder(nil, nil, notifier, window)
+
+			err := reminder.remind(session, &models.MissionsResponse{Weekly: tt.missions}, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.want == nil {
+				if len(notifier.reminders) != 0 {
+					t.Errorf("不应发送提醒: %+v", notifier.reminders[0])
+				}
+				return
+			}
+			if len(notifier.reminders) != 1 {
+				t.Fatalf("发送了%d次提醒，期望1次", len(notifier.reminders))
+			}
+
+			sent := notifier.reminders[0]
+			var got []string
+			for _, mission := range sent.Missions {
+				got = append(got, mission.ID)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("提醒的任务为%v，期望%v", got, tt.want)
+			}
+			if sent.UserID != "user-1" || sent.Username != "Player#0001" || sent.RemainingXP != tt.wantXP || !sent.ResetsAt.Equal(tt.wantResets) {
+				t.Errorf("提醒为%+v", sent)
+			}
+		})
+	}
+}
+
+func TestMissionReminderOncePerReset(t *testing.T) {
+	now := time.Date(2024, 1, 22, 12, 0, 0, 0, time.UTC)
+	session := &models.UserSession{UserID: "user-1"}
+	missionsResetting := func(resetsAt time.Time) *models.MissionsResponse {
+		return &models.MissionsResponse{Weekly: []models.Mission{{ID: "open", XP: 8000, ExpiresAt: resetsAt}}}
+	}
+	firstReset := now.Add(time.Hour)
+
+	notifier := &stubNotifier{err: errors.New("webhook不可用")}
+	reminder := NewMissionReminder(nil, nil, notifier, 24*time.Hour)
+
+	// 发送失败时不记录，下一次检查重试
+	if err := reminder.remind(session, missionsResetting(firstReset), now); err == nil {
+		t.Fatal("发送失败时应返回错误")
+	}
+	notifier.err = nil
+	for i := 0; i < 2; i++ {
+		if err := reminder.remind(session, missionsResetting(firstReset), now.Add(time.Duration(i)*time.Minute)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(notifier.reminders) != 1 {
+		t.Fatalf("同一次刷新发送了%d次提醒，期望1次", len(notifier.reminders))
+	}
+
+	// 下一周的任务再次提醒
+	secondReset := firstReset.Add(7 * 24 * time.Hour)
+	if err := reminder.remind(session, missionsResetting(secondReset), secondReset.Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if len(notifier.reminders) != 2 || !notifier.reminders[1].ResetsAt.Equal(secondReset) {
+		t.Errorf("下一次刷新的提醒为%+v", notifier.reminders)
+	}
+}
+
+func TestMissionReminderPrune(t *testing.T) {
+	now := time.Date(2024, 1, 22, 12, 0, 0, 0, time.UTC)
+	reminder := NewMissionReminder(nil, nil, &stubNotifier{}, time.Hour)
+	reminder.notified["expired"] = now.Add(-time.Minute)
+	reminder.notified["resetting"] = now
+	reminder.notified["pending"] = now.Add(time.Minute)
+
+	reminder.prune(now)
+
+	if len(reminder.notified) != 1 || !reminder.notified["pending"].Equal(now.Add(time.Minute)) {
+		t.Errorf("剩余的提醒记录为%v，期望只有pending", reminder.notified)
+	}
+}
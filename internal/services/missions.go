@@ -0,0 +1,77 @@
+package services
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/emper0r/val-store-server/internal/models"
+	"github.com/emper0r/val-store-server/internal/repositories"
+)
+
+// GetMissions 获取每日任务和每周任务的进度、经验奖励和过期时间
+// 任务的类型、标题和目标只能从内容目录中获得，目录不可用时返回ErrCatalogUnavailable
+func (s *ContractService) GetMissions(session *models.UserSession, language string) (*models.MissionsResponse, error) {
+	client, err := s.valorantAPI.NewClient(session)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := loadCatalog(s.contentCatalog, language)
+	if catalog == nil {
+		return nil, ErrCatalogUnavailable
+	}
+
+	contracts, err := client.GetContracts()
+	if err != nil {
+		return nil, err
+	}
+
+	return buildMissions(contracts, catalog), nil
+}
+
+// buildMissions 将Riot的任务进度和内容目录中的任务定义合并，并按类型分组
+func buildMissions(contracts *models.ValorantContractsResponse, catalog *repositories.Catalog) *models.MissionsResponse {
+	response := &models.MissionsResponse{
+		Daily:            []models.Mission{},
+		Weekly:           []models.Mission{},
+		WeeklyRefillTime: contracts.MissionMetadata.WeeklyRefillTime,
+	}
+
+	for _, riotMission := range contracts.Missions {
+		mission := models.Mission{
+			ID:        riotMission.ID,
+			Complete:  riotMission.Complete,
+			ExpiresAt: riotMission.ExpirationTime,
+		}
+		for _, progress := range riotMission.Objectives {
+			mission.Progress += progress
+		}
+
+		content, ok := catalog.Missions[strings.ToLower(riotMission.ID)]
+		if !ok {
+			continue
+		}
+		mission.Title = content.Title
+		mission.XP = content.XPGrant
+		mission.Target = content.ProgressToComplete
+		if mission.Complete && mission.Target > 0 {
+			mission.Progress = mission.Target
+		}
+
+		switch content.Type {
+		case models.MissionTypeDaily:
+			response.Daily = append(response.Daily, mission)
+		case models.MissionTypeWeekly:
+			response.Weekly = append(response.Weekly, mission)
+		}
+	}
+
+	// 未完成的任务排在前面
+	for _, missions := range [][]models.Mission{response.Daily, response.Weekly} {
+		sort.SliceStable(missions, func(i, j int) bool {
+			return !missions[i].Complete && missions[j].Complete
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/emper0r/val-store-server/internal/models"
+	"github.com/emper0r/val-store-server/internal/repositories"
+)
+
+// testMissionsJSON Riot合约接口中的任务进度，多目标任务的进度为各目标之和
+const testMissionsJSON = `{
+	"Missions": [
+		{"ID": "daily-done", "Objectives": {"o1": 3}, "Complete": true, "ExpirationTime": "2024-01-21T00:00:00Z"},
+		{"ID": "DAILY-OPEN", "Objectives": {"o1": 1, "o2": 2}, "Complete": false, "ExpirationTime": "2024-01-21T00:00:00Z"},
+		{"ID": "weekly-done", "Objectives": {}, "Complete": true, "ExpirationTime": "2024-01-23T00:00:00Z"},
+		{"ID": "weekly-open", "Objectives": {"o1": 40}, "Complete": false, "ExpirationTime": "2024-01-23T00:00:00Z"},
+		{"ID": "weekly-no-target", "Objectives": {"o1": 7}, "Complete": true, "ExpirationTime": "2024-01-23T00:00:00Z"},
+		{"ID": "unknown", "Objectives": {"o1": 1}, "Complete": false, "ExpirationTime": "2024-01-23T00:00:00Z"}
+	],
+	"MissionMetadata": {"WeeklyRefillTime": "2024-01-23T00:00:00Z"}
+}`
+
+func TestBuildMissions(t *testing.T) {
+	var contracts models.ValorantContractsResponse
+	if err := json.Unmarshal([]byte(testMissionsJSON), &contracts); err != nil {
+		t.Fatal(err)
+	}
+	catalog := &repositories.Catalog{Missions: map[string]*models.ContentMission{
+		"daily-done":       {Title: "每日1", Type: models.MissionTypeDaily, XPGrant: 2000, ProgressToComplete: 5},
+		"daily-open":       {Title: "每日2", Type: models.MissionTypeDaily, XPGrant: 2000, ProgressToComplete: 10},
+		"weekly-done":      {Title: "每周1", Type: models.MissionTypeWeekly, XPGrant: 8000, ProgressToComplete: 100},
+		"weekly-open":      {Title: "每周2", Type: models.MissionTypeWeekly, XPGrant: 8000, ProgressToComplete: 100},
+		"weekly-no-target": {Title: "每周3", Type: models.MissionTypeWeekly, XPGrant: 8000},
+	}}
+
+	response := buildMissions(&contracts, catalog)
+
+	// 未完成的任务排在前面，目录中没有的任务被跳过
+	format := func(missions []models.Mission) []string {
+		var result []string
+		for _, mission := range missions {
+			result = append(result, fmt.Sprintf("%s:%d/%d", mission.ID, mission.Progress, mission.Target))
+		}
+		return result
+	}
+	tests := []struct {
+		name string
+		got  []models.Mission
+		want []string
+	}{
+		// 完成的任务进度等于目标，Riot可能返回少于目标的进度
+		{name: "每日任务", got: response.Daily, want: []string{"DAILY-OPEN:3/10", "daily-done:5/5"}},
+		// 没有目标的已完成任务保留Riot的进度
+		{name: "每周任务", got: response.Weekly, want: []string{"weekly-open:40/100", "weekly-done:100/100", "weekly-no-target:7/0"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := format(tt.got); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("任务为%v，期望%v", got, tt.want)
+			}
+		})
+	}
+
+	open := response.Weekly[0]
+	if open.Title != "每周2" || open.XP != 8000 || open.Complete ||
+		!open.ExpiresAt.Equal(time.Date(2024, 1, 23, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("每周任务为%+v", open)
+	}
+	if !response.WeeklyRefillTime.Equal(open.ExpiresAt) {
+		t.Errorf("每周刷新时间为%v", response.WeeklyRefillTime)
+	}
+}
+
+func TestBuildMissionsEmpty(t *testing.T) {
+	response := buildMissions(&models.ValorantContractsResponse{}, &repositories.Catalog{})
+
+	// 没有任务时返回空数组而不是null
+	if response.Daily == nil || response.Weekly == nil || len(response.Daily)+len(response.Weekly) != 0 {
+		t.Errorf("任务为%+v", response)
+	}
+}
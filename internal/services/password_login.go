@@ -0,0 +1,231 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/emper0r/val-store-server/internal/models"
+	"github.com/emper0r/val-store-server/internal/repositories"
+)
+
+// 等待验证码或人机验证的登录保留多久
+const pendingLoginTTL = 5 * time.Minute
+
+// 同一次登录最多允许输错几次邮箱验证码
+const maxMFAAttempts = 5
+
+// 最多同时保存多少个等待完成的登录，登录接口不需要认证，避免被用来占满内存
+const maxPendingLogins = 1000
+
+// ErrLoginExpired login_id不存在或已过期
+var ErrLoginExpired = errors.New("登录已过期，请重新输入账号密码")
+
+// ErrMFAAttemptsExceeded 邮箱验证码输错次数过多，需要重新登录
+var ErrMFAAttemptsExceeded = errors.New("验证码错误次数过多，请重新输入账号密码")
+
+// ErrLoginStepMismatch login_id对应的登录正在等待另一种挑战，例如用人机验证的login_id提交邮箱验证码
+var ErrLoginStepMismatch = errors.New("login_id对应的登录不需要这一步验证")
+
+// ErrTooManyPendingLogins 等待完成的登录已达到上限
+var ErrTooManyPendingLogins = errors.New("等待完成的登录过多，请稍后再试")
+
+// ErrLoginInternal 登录成功后服务器保存会话失败
+var ErrLoginInternal = errors.New("创建会话失败")
+
+// Riot返回的账号密码登录错误
+var (
+	ErrInvalidCredentials = repositories.ErrInvalidCredentials
+	ErrInvalidMFACode     = repositories.ErrInvalidMFACode
+	ErrLoginRateLimited   = repositories.ErrLoginRateLimited
+)
+
+// pendingLogin 等待用户完成挑战的登录
+type pendingLogin struct {
+	login       *repositories.PasswordLogin
+	challenge   string // 等待的挑战类型，models.LoginChallengeCaptcha或models.LoginChallengeMFA
+	expiresAt   time.Time
+	mfaAttempts int // 已输错的验证码次数
+}
+
+// pendingLogins 在内存中保存等待完成的登录，过期的登录在访问时清理
+type pendingLogins struct {
+	mu     sync.Mutex
+	ttl    time.Duration
+	max    int
+	logins map[string]pendingLogin
+}
+
+// newPendingLogins 创建最多保存max个登录的等待表
+func newPendingLogins(ttl time.Duration, max int) *pendingLogins {
+	return &pendingLogins{
+		ttl:    ttl,
+		max:    max,
+		logins: make(map[string]pendingLogin),
+	}
+}
+
+// full 判断是否已达到上限，开始新的登录前检查，避免向Riot发起注定无法保存的登录
+// 继续已有的登录不受上限限制
+func (p *pendingLogins) full() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.prune(time.Now())
+	return len(p.logins) >= p.max
+}
+
+// put 保存进入新挑战的登录，返回过期时间
+func (p *pendingLogins) put(id string, login *repositories.PasswordLogin, challenge string) time.Time {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	p.prune(now)
+
+	expiresAt := now.Add(p.ttl)
+	p.logins[id] = pendingLogin{login: login, challenge: challenge, expiresAt: expiresAt}
+	return expiresAt
+}
+
+// restore 放回取出的登录，保留原来的过期时间和错误次数
+func (p *pendingLogins) restore(id string, pending pendingLogin) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.logins[id] = pending
+}
+
+// take 取出等待challenge的登录，同一个login_id不能被并发使用
+// 挑战类型不符时登录保留在表中，不影响用户用正确的接口继续
+func (p *pendingLogins) take(id, challenge string) (pendingLogin, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.prune(time.Now())
+
+	pending, ok := p.logins[id]
+	if !ok {
+		return pendingLogin{}, ErrLoginExpired
+	}
+	if pending.challenge != challenge {
+		return pendingLogin{}, ErrLoginStepMismatch
+	}
+	delete(p.logins, id)
+	return pending, nil
+}
+
+// prune 删除已过期的登录，调用方需持有锁
+func (p *pendingLogins) prune(now time.Time) {
+	for id, pending := range p.logins {
+		if now.After(pending.expiresAt) {
+			delete(p.logins, id)
+		}
+	}
+}
+
+// LoginWithPassword 使用Riot账号密码登录
+// 需要人机验证或邮箱验证码时返回挑战，客户端完成后使用其中的login_id继续登录
+func (s *AuthService) LoginWithPassword(request models.PasswordLoginRequest) (*models.UserTokensResponse, *models.LoginChallenge, error) {
+	var (
+		login   *repositories.PasswordLogin
+		pending pendingLogin
+	)
+	if request.LoginID != "" {
+		// 完成人机验证后在同一个登录流程中提交账号密码
+		var err error
+		pending, err = s.logins.take(request.LoginID, models.LoginChallengeCaptcha)
+		if err != nil {
+			return nil, nil, err
+		}
+		login = pending.login
+	} else {
+		if s.logins.full() {
+			return nil, nil, ErrTooManyPendingLogins
+		}
+
+		var (
+			captcha *models.CaptchaChallenge
+			err     error
+		)
+		login, captcha, err = s.valorantAPI.StartPasswordLogin(request.Region)
+		if err != nil {
+			return nil, nil, err
+		}
+		if captcha != nil {
+			// 人机验证的令牌与本次登录绑定，需要客户端完成验证后重新提交
+			return s.finishPasswordLogin("", login, &repositories.PasswordLoginResult{Captcha: captcha})
+		}
+	}
+
+	result, err := login.SubmitCredentials(request.Username, request.Password, request.CaptchaToken)
+	if err != nil {
+		if request.LoginID != "" && !errors.Is(err, ErrInvalidCredentials) {
+			// 网络错误、Riot服务错误或频率限制时允许用户在原来的有效期内重试
+			s.logins.restore(request.LoginID, pending)
+		}
+		return nil, nil, err
+	}
+
+	return s.finishPasswordLogin(request.LoginID, login, result)
+}
+
+// SubmitMFA 提交邮箱验证码完成登录
+func (s *AuthService) SubmitMFA(request models.MFALoginRequest) (*models.UserTokensResponse, *models.LoginChallenge, error) {
+	pending, err := s.logins.take(request.LoginID, models.LoginChallengeMFA)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, err := pending.login.SubmitMFA(request.Code, request.RememberDevice)
+	if errors.Is(err, ErrInvalidMFACode) {
+		// 验证码输错时允许用户在原来的有效期内重新输入
+		pending.mfaAttempts++
+		if pending.mfaAttempts >= maxMFAAttempts {
+			return nil, nil, ErrMFAAttemptsExceeded
+		}
+		s.logins.restore(request.LoginID, pending)
+		return nil, nil, err
+	}
+	if err != nil {
+		// 网络错误、Riot服务错误或频率限制不计入错误次数
+		s.logins.restore(request.LoginID, pending)
+		return nil, nil, err
+	}
+
+	return s.finishPasswordLogin(request.LoginID, pending.login, result)
+}
+
+// finishPasswordLogin 登录成功时创建会话，否则保存登录并返回挑战
+func (s *AuthService) finishPasswordLogin(loginID string, login *repositories.PasswordLogin, result *repositories.PasswordLoginResult) (*models.UserTokensResponse, *models.LoginChallenge, error) {
+	if result.Session != nil {
+		response, err := s.createSession(result.Session)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %w", ErrLoginInternal, err)
+		}
+		return response, nil, nil
+	}
+
+	if loginID == "" {
+		var err error
+		loginID, err = newSessionID()
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: 生成登录ID失败: %w", ErrLoginInternal, err)
+		}
+	}
+
+	challenge := &models.LoginChallenge{
+		LoginID:     loginID,
+		Multifactor: result.MFA,
+		Captcha:     result.Captcha,
+	}
+	if result.Captcha != nil {
+		challenge.Type = models.LoginChallengeCaptcha
+	} else {
+		challenge.Type = models.LoginChallengeMFA
+	}
+	challenge.ExpiresAt = s.logins.put(loginID, login, challenge.Type)
+
+	return nil, challenge, nil
+}
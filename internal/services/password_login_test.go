@@ -0,0 +1,355 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/emper0r/val-store-server/internal/models"
+	"github.com/emper0r/val-store-server/internal/repositories"
+)
+
+// newFakeLoginServer 创建模拟Riot账号密码登录的本地服务器
+// requireCaptcha为true时开始登录会下发人机验证，只有令牌为ok时才能通过
+// 用户名mfa需要验证码123456，验证码unavailable返回502，用户名limited触发限流，密码wrong返回auth_failure
+func newFakeLoginServer(t *testing.T, requireCaptcha bool) *httptest.Server {
+	t.Helper()
+
+	captcha := `"captcha": {"type": "hcaptcha", "hcaptcha": {"key": "site-key", "data": "rqdata"}}`
+	writeJSON := func(w http.ResponseWriter, body string) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "asid", Value: "asid-1"})
+		writeJSON(w, `{"type": "auth"}`)
+	})
+	mux.HandleFunc("/authenticate", func(w http.ResponseWriter, r *http.Request) {
+		// 同一次登录的请求必须共享cookie jar
+		if _, err := r.Cookie("asid"); err != nil {
+			http.Error(w, "missing asid", http.StatusBadRequest)
+			return
+		}
+
+		if r.Method == http.MethodPost {
+			if requireCaptcha {
+				writeJSON(w, `{"type": "auth", `+captcha+`}`)
+			} else {
+				writeJSON(w, `{"type": "auth"}`)
+			}
+			return
+		}
+
+		var body struct {
+			Type         string `json:"type"`
+			RiotIdentity struct {
+				Captcha  string `json:"captcha"`
+				Username string `json:"username"`
+				Password string `json:"password"`
+			} `json:"riot_identity"`
+			Multifactor struct {
+				OTP string `json:"otp"`
+			} `json:"multifactor"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if body.Type == "multifactor" {
+			if body.Multifactor.OTP == "unavailable" {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			if body.Multifactor.OTP != "123456" {
+				writeJSON(w, `{"type": "multifactor", "error": "multifactor_attempt_failed"}`)
+				return
+			}
+			writeJSON(w, `{"type": "success", "success": {"login_token": "mfa"}}`)
+			return
+		}
+
+		identity := body.RiotIdentity
+		switch {
+		case requireCaptcha && identity.Captcha != "hcaptcha ok":
+			writeJSON(w, `{"type": "auth", "error": "captcha_not_allowed", `+captcha+`}`)
+		case identity.Username == "limited":
+			w.WriteHeader(http.StatusTooManyRequests)
+		case identity.Password == "wrong":
+			writeJSON(w, `{"type": "auth", "error": "auth_failure"}`)
+		case identity.Username == "mfa":
+			writeJSON(w, `{"type": "multifactor", "multifactor": {"email": "m***@example.com", "method": "email", "methods": ["email"]}}`)
+		default:
+			writeJSON(w, `{"type": "success", "success": {"login_token": "`+identity.Username+`"}}`)
+		}
+	})
+	mux.HandleFunc("/login-token", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			LoginToken string `json:"login_token"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		http.SetCookie(w, &http.Cookie{Name: "ssid", Value: "ssid-" + body.LoginToken})
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/authorize", func(w http.ResponseWriter, r *http.Request) {
+		ssid, err := r.Cookie("ssid")
+		if err != nil {
+			w.Header().Set("Location", "https://authenticate.riotgames.com/login")
+			w.WriteHeader(http.StatusSeeOther)
+			return
+		}
+		w.Header().Set("Location", "https://playvalorant.com/opt_in#access_token=at-"+ssid.Value+"&expires_in=3600")
+		w.WriteHeader(http.StatusSeeOther)
+	})
+	mux.HandleFunc("/entitlements", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(models.ValorantEntitlementResponse{EntitlementToken: "ent"})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		var info models.ValorantUserInfoResponse
+		info.Sub = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer at-")
+		info.Acct.GameName = "player"
+		info.Acct.TagLine = "tag"
+		json.NewEncoder(w).Encode(info)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newPasswordLoginService 创建连接本地服务器的认证服务
+func newPasswordLoginService(t *testing.T, requireCaptcha bool) (*AuthService, repositories.SessionStore) {
+	t.Helper()
+
+	server := newFakeLoginServer(t, requireCaptcha)
+	store := repositories.NewMemorySessionStore()
+	return NewAuthService(repositories.NewValorantAPIWithBaseURL(server.URL, "release-test"), store), store
+}
+
+// assertSavedSSID 检查保存的会话中包含登录时下发的ssid
+func assertSavedSSID(t *testing.T, store repositories.SessionStore, want string) {
+	t.Helper()
+
+	sessions, err := store.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 1 {
+		t.Fatalf("保存了%d个会话，期望1个", len(sessions))
+	}
+	for _, session := range sessions {
+		if session.Cookies["ssid"] != want || session.UserID != want {
+			t.Errorf("会话为%+v，期望ssid和用户ID为%q", session, want)
+		}
+	}
+}
+
+func TestLoginWithPasswordSuccess(t *testing.T) {
+	service, store := newPasswordLoginService(t, false)
+
+	response, challenge, err := service.LoginWithPassword(models.PasswordLoginRequest{Username: "alice", Password: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if challenge != nil || response == nil || response.Token == "" {
+		t.Fatalf("应直接登录成功: response=%+v challenge=%+v", response, challenge)
+	}
+	assertSavedSSID(t, store, "ssid-alice")
+}
+
+func TestLoginWithPasswordMFA(t *testing.T) {
+	service, store := newPasswordLoginService(t, false)
+
+	_, challenge, err := service.LoginWithPassword(models.PasswordLoginRequest{Username: "mfa", Password: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if challenge == nil || challenge.Type != models.LoginChallengeMFA || challenge.Multifactor.Email != "m***@example.com" {
+		t.Fatalf("应要求输入验证码: %+v", challenge)
+	}
+
+	// 输错验证码后登录被放回，过期时间保持不变
+	_, _, err = service.SubmitMFA(models.MFALoginRequest{LoginID: challenge.LoginID, Code: "000000"})
+	if !errors.Is(err, ErrInvalidMFACode) {
+		t.Fatalf("错误为%v，期望%v", err, ErrInvalidMFACode)
+	}
+	pending, err := service.logins.take(challenge.LoginID, models.LoginChallengeMFA)
+	if err != nil || !pending.expiresAt.Equal(challenge.ExpiresAt) || pending.mfaAttempts != 1 {
+		t.Fatalf("输错验证码后的登录不正确: %+v", pending)
+	}
+	service.logins.restore(challenge.LoginID, pending)
+
+	// Riot服务不可用时登录被放回，不计入错误次数
+	if _, _, err := service.SubmitMFA(models.MFALoginRequest{LoginID: challenge.LoginID, Code: "unavailable"}); err == nil {
+		t.Fatal("Riot服务不可用时应返回错误")
+	}
+	pending, err = service.logins.take(challenge.LoginID, models.LoginChallengeMFA)
+	if err != nil || pending.mfaAttempts != 1 {
+		t.Fatalf("Riot服务不可用后的登录不正确: %+v, %v", pending, err)
+	}
+	service.logins.restore(challenge.LoginID, pending)
+
+	// 验证码的login_id不能用来提交账号密码，登录仍然保留
+	_, _, err = service.LoginWithPassword(models.PasswordLoginRequest{Username: "mfa", Password: "secret", LoginID: challenge.LoginID})
+	if !errors.Is(err, ErrLoginStepMismatch) {
+		t.Fatalf("错误为%v，期望%v", err, ErrLoginStepMismatch)
+	}
+
+	response, next, err := service.SubmitMFA(models.MFALoginRequest{LoginID: challenge.LoginID, Code: "123456"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next != nil || response == nil {
+		t.Fatalf("应登录成功: response=%+v challenge=%+v", response, next)
+	}
+	assertSavedSSID(t, store, "ssid-mfa")
+
+	// 登录完成后login_id失效
+	if _, _, err := service.SubmitMFA(models.MFALoginRequest{LoginID: challenge.LoginID, Code: "123456"}); !errors.Is(err, ErrLoginExpired) {
+		t.Errorf("错误为%v，期望%v", err, ErrLoginExpired)
+	}
+}
+
+func TestSubmitMFAAttemptsExceeded(t *testing.T) {
+	service, _ := newPasswordLoginService(t, false)
+
+	_, challenge, err := service.LoginWithPassword(models.PasswordLoginRequest{Username: "mfa", Password: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 1; i < maxMFAAttempts; i++ {
+		if _, _, err := service.SubmitMFA(models.MFALoginRequest{LoginID: challenge.LoginID, Code: "000000"}); !errors.Is(err, ErrInvalidMFACode) {
+			t.Fatalf("第%d次错误为%v，期望%v", i, err, ErrInvalidMFACode)
+		}
+	}
+	if _, _, err := service.SubmitMFA(models.MFALoginRequest{LoginID: challenge.LoginID, Code: "000000"}); !errors.Is(err, ErrMFAAttemptsExceeded) {
+		t.Fatalf("错误为%v，期望%v", err, ErrMFAAttemptsExceeded)
+	}
+	if _, _, err := service.SubmitMFA(models.MFALoginRequest{LoginID: challenge.LoginID, Code: "123456"}); !errors.Is(err, ErrLoginExpired) {
+		t.Errorf("错误为%v，期望%v", err, ErrLoginExpired)
+	}
+}
+
+func TestLoginWithPasswordCaptcha(t *testing.T) {
+	service, store := newPasswordLoginService(t, true)
+	request := models.PasswordLoginRequest{Username: "bob", Password: "secret"}
+
+	_, challenge, err := service.LoginWithPassword(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := models.CaptchaChallenge{Provider: "hcaptcha", SiteKey: "site-key", Data: "rqdata"}
+	if challenge == nil || challenge.Type != models.LoginChallengeCaptcha || challenge.Captcha == nil || *challenge.Captcha != want {
+		t.Fatalf("应要求人机验证: %+v", challenge)
+	}
+
+	// 人机验证的login_id不能用来提交验证码
+	if _, _, err := service.SubmitMFA(models.MFALoginRequest{LoginID: challenge.LoginID, Code: "123456"}); !errors.Is(err, ErrLoginStepMismatch) {
+		t.Fatalf("错误为%v，期望%v", err, ErrLoginStepMismatch)
+	}
+
+	// 验证令牌无效时返回新的人机验证，login_id保持不变
+	request.LoginID = challenge.LoginID
+	request.CaptchaToken = "bad"
+	_, retry, err := service.LoginWithPassword(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if retry == nil || retry.Type != models.LoginChallengeCaptcha || retry.LoginID != challenge.LoginID {
+		t.Fatalf("应重新要求人机验证: %+v", retry)
+	}
+
+	// 限流时登录被放回，可以用同一个login_id重试
+	request.CaptchaToken = "ok"
+	limited := request
+	limited.Username = "limited"
+	if _, _, err := service.LoginWithPassword(limited); !errors.Is(err, ErrLoginRateLimited) {
+		t.Fatalf("错误为%v，期望%v", err, ErrLoginRateLimited)
+	}
+
+	response, next, err := service.LoginWithPassword(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next != nil || response == nil {
+		t.Fatalf("应登录成功: response=%+v challenge=%+v", response, next)
+	}
+	assertSavedSSID(t, store, "ssid-bob")
+}
+
+func TestLoginWithPasswordErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		request models.PasswordLoginRequest
+		wantErr error
+	}{
+		{name: "密码错误", request: models.PasswordLoginRequest{Username: "alice", Password: "wrong"}, wantErr: ErrInvalidCredentials},
+		{name: "请求过于频繁", request: models.PasswordLoginRequest{Username: "limited", Password: "secret"}, wantErr: ErrLoginRateLimited},
+		{name: "login_id不存在", request: models.PasswordLoginRequest{Username: "alice", Password: "secret", LoginID: "unknown"}, wantErr: ErrLoginExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, store := newPasswordLoginService(t, false)
+
+			_, _, err := service.LoginWithPassword(tt.request)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("错误为%v，期望%v", err, tt.wantErr)
+			}
+			if sessions, _ := store.All(); len(sessions) != 0 {
+				t.Errorf("登录失败时不应保存会话: %+v", sessions)
+			}
+		})
+	}
+}
+
+func TestLoginWithPasswordCaptchaInvalidCredentials(t *testing.T) {
+	service, _ := newPasswordLoginService(t, true)
+	request := models.PasswordLoginRequest{Username: "bob", Password: "wrong"}
+
+	_, challenge, err := service.LoginWithPassword(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 人机验证令牌已被使用，密码错误后需要重新开始登录
+	request.LoginID = challenge.LoginID
+	request.CaptchaToken = "ok"
+	if _, _, err := service.LoginWithPassword(request); !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("错误为%v，期望%v", err, ErrInvalidCredentials)
+	}
+	if _, _, err := service.LoginWithPassword(request); !errors.Is(err, ErrLoginExpired) {
+		t.Errorf("错误为%v，期望%v", err, ErrLoginExpired)
+	}
+}
+
+func TestLoginWithPasswordPendingLimit(t *testing.T) {
+	service, store := newPasswordLoginService(t, false)
+	service.logins = newPendingLogins(pendingLoginTTL, 1)
+
+	_, challenge, err := service.LoginWithPassword(models.PasswordLoginRequest{Username: "mfa", Password: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := service.LoginWithPassword(models.PasswordLoginRequest{Username: "alice", Password: "secret"}); !errors.Is(err, ErrTooManyPendingLogins) {
+		t.Fatalf("错误为%v，期望%v", err, ErrTooManyPendingLogins)
+	}
+
+	// 达到上限时仍然可以继续已有的登录，完成后释放名额
+	if _, _, err := service.SubmitMFA(models.MFALoginRequest{LoginID: challenge.LoginID, Code: "123456"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := service.LoginWithPassword(models.PasswordLoginRequest{Username: "alice", Password: "secret"}); err != nil {
+		t.Fatal(err)
+	}
+	if sessions, _ := store.All(); len(sessions) != 2 {
+		t.Errorf("保存了%d个会话，期望2个", len(sessions))
+	}
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/emper0r/val-store-server/internal/models"
+	"github.com/emper0r/val-store-server/internal/repositories"
+)
+
+// 访问令牌在过期前多久重新认证
+const tokenRefreshMargin = 10 * time.Minute
+
+// ErrSessionDead Riot会话的Cookie已失效，只能重新登录
+var ErrSessionDead = errors.New("Riot会话已失效，请重新登录")
+
+// sessionLocks 按会话ID加锁，避免同一会话被并发重新认证
+type sessionLocks struct {
+	locks sync.Map
+}
+
+// lock 锁定指定会话，返回解锁函数
+func (l *sessionLocks) lock(id string) func() {
+	value, _ := l.locks.LoadOrStore(id, &sync.Mutex{})
+	mu := value.(*sync.Mutex)
+	mu.Lock()
+	return mu.Unlock
+}
+
+// forget 删除会话对应的锁，会话被删除或失效后调用，避免锁表无限增长
+// 仍在等待旧锁的请求会重新读取会话并发现它已不可用，因此不会并发刷新
+func (l *sessionLocks) forget(id string) {
+	l.locks.Delete(id)
+}
+
+// retain 删除不在sessions中的会话的锁，例如已过期被存储清理的会话
+// sessions只是某一时刻的快照，正在被持有的锁属于刚保存的会话或进行中的刷新，跳过不删除
+func (l *sessionLocks) retain(sessions map[string]*models.UserSession) {
+	l.locks.Range(func(key, value interface{}) bool {
+		if _, ok := sessions[key.(string)]; ok {
+			return true
+		}
+		mu := value.(*sync.Mutex)
+		if mu.TryLock() {
+			l.locks.Delete(key)
+			mu.Unlock()
+		}
+		return true
+	})
+}
+
+// needsRefresh 判断会话的访问令牌是否即将过期
+func needsRefresh(session *models.UserSession, now time.Time) bool {
+	return !session.Dead && now.Add(tokenRefreshMargin).After(session.TokenExpiresAt)
+}
+
+// RefreshSession 使用保存的Cookie重放授权流程，更新访问令牌和轮换后的Cookie
+func (s *AuthService) RefreshSession(id string) (*models.UserSession, error) {
+	unlock := s.refreshLocks.lock(id)
+	defer unlock()
+
+	// 加锁后重新读取，其他请求可能已经完成了刷新
+	session, err := s.sessionStore.Get(id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrSessionNotFound) {
+			s.refreshLocks.forget(id)
+		}
+		return nil, err
+	}
+	if session.Dead {
+		s.refreshLocks.forget(id)
+		return nil, ErrSessionDead
+	}
+	if !needsRefresh(session, time.Now()) {
+		return session, nil
+	}
+
+	refreshed, err := s.valorantAPI.AuthenticateWithCookies(session.Cookies, session.Region)
+	if errors.Is(err, repositories.ErrCookiesExpired) || (err == nil && refreshed.UserID != session.UserID) {
+		// 重定向到登录页说明Cookie已失效，标记会话死亡
+		session.Dead = true
+		if saveErr := s.sessionStore.Save(id, session); saveErr != nil {
+			log.Printf("保存失效会话失败: %v", saveErr)
+		}
+		s.refreshLocks.forget(id)
+		return nil, ErrSessionDead
+	}
+	if err != nil {
+		return nil, fmt.Errorf("重新认证失败: %w", err)
+	}
+
+	session.AccessToken = refreshed.AccessToken
+	session.Entitlement = refreshed.Entitlement
+	session.TokenExpiresAt = refreshed.TokenExpiresAt
+	session.Cookies = refreshed.Cookies
+
+	if err := s.sessionStore.Save(id, session); err != nil {
+		return nil, fmt.Errorf("保存会话失败: %w", err)
+	}
+
+	return session, nil
+}
+
+// RefreshExpiringSessions 重新认证所有即将过期的会话
+func (s *AuthService) RefreshExpiringSessions() {
+	sessions, err := s.sessionStore.All()
+	if err != nil {
+		log.Printf("读取会话列表失败: %v", err)
+		return
+	}
+	s.refreshLocks.retain(sessions)
+
+	now := time.Now()
+	for id, session := range sessions {
+		if !needsRefresh(session, now) {
+			continue
+		}
+		if _, err := s.RefreshSession(id); err != nil {
+			log.Printf("会话 %s (%s) 重新认证失败: %v", id, session.UserID, err)
+		}
+	}
+}
+
+// StartSessionRefresher 在后台定期重新认证即将过期的会话，直到ctx被取消
+func (s *AuthService) StartSessionRefresher(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.RefreshExpiringSessions()
+		}
+	}
+}
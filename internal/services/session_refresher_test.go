@@ -0,0 +1,163 @@
+package services
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/emper0r/val-store-server/internal/models"
+	"github.com/emper0r/val-store-server/internal/repositories"
+	"github.com/emper0r/val-store-server/internal/repositories/riottest"
+)
+
+// newRefreshTestService 创建连接模拟Riot服务器的认证服务，并保存一个令牌即将过期的会话
+// 模拟服务器返回的用户ID为puuid-<ssid>
+func newRefreshTestService(t *testing.T, userID string, cookies map[string]string) (*AuthService, repositories.SessionStore) {
+	t.Helper()
+
+	server := riottest.NewServer(t)
+	store := repositories.NewMemorySessionStore()
+	service := NewAuthService(repositories.NewValorantAPIWithBaseURL(server.URL, "release-test"), store)
+
+	session := &models.UserSession{
+		UserID:         userID,
+		AccessToken:    "old-token",
+		Cookies:        cookies,
+		ExpiresAt:      time.Now().Add(time.Hour),
+		TokenExpiresAt: time.Now().Add(time.Minute),
+	}
+	if err := store.Save("s1", session); err != nil {
+		t.Fatal(err)
+	}
+	return service, store
+}
+
+func TestNeedsRefresh(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		session models.UserSession
+		want    bool
+	}{
+		{name: "令牌还有一小时", session: models.UserSession{TokenExpiresAt: now.Add(time.Hour)}, want: false},
+		{name: "令牌即将过期", session: models.UserSession{TokenExpiresAt: now.Add(tokenRefreshMargin - time.Minute)}, want: true},
+		{name: "令牌已过期", session: models.UserSession{TokenExpiresAt: now.Add(-time.Minute)}, want: true},
+		{name: "没有记录过期时间", session: models.UserSession{}, want: true},
+		{name: "会话已失效", session: models.UserSession{Dead: true}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := needsRefresh(&tt.session, now); got != tt.want {
+				t.Errorf("needsRefresh() = %v，期望%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefreshSessionSavesRotatedCookies(t *testing.T) {
+	service, store := newRefreshTestService(t, "puuid-rotate", map[string]string{"ssid": "rotate", "clid": "old-clid"})
+
+	refreshed, err := service.RefreshSession("s1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refreshed.AccessToken != "at-rotate" || refreshed.Entitlement != "ent-rotate" {
+		t.Errorf("令牌没有更新: %+v", refreshed)
+	}
+
+	saved, err := store.Get("s1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Cookies["ssid"] != "rotated-ssid" || saved.Cookies["clid"] != "rotated-clid" {
+		t.Errorf("没有保存轮换后的Cookie: %+v", saved.Cookies)
+	}
+	if saved.AccessToken != "at-rotate" || needsRefresh(saved, time.Now()) {
+		t.Errorf("保存的会话仍需要刷新: %+v", saved)
+	}
+}
+
+func TestRefreshSessionMarksDead(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  string
+		cookies map[string]string
+	}{
+		// 缺少ssid时模拟服务器重定向到登录页
+		{name: "Cookie已失效", userID: "puuid-x", cookies: map[string]string{"csid": "x"}},
+		{name: "Cookie属于其他账号", userID: "puuid-me", cookies: map[string]string{"ssid": "other"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, store := newRefreshTestService(t, tt.userID, tt.cookies)
+
+			if _, err := service.RefreshSession("s1"); !errors.Is(err, ErrSessionDead) {
+				t.Fatalf("错误为%v，期望%v", err, ErrSessionDead)
+			}
+			saved, err := store.Get("s1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !saved.Dead || saved.AccessToken != "old-token" {
+				t.Errorf("会话没有被标记为失效: %+v", saved)
+			}
+
+			// 失效的会话不再重新认证
+			if _, err := service.RefreshSession("s1"); !errors.Is(err, ErrSessionDead) {
+				t.Errorf("错误为%v，期望%v", err, ErrSessionDead)
+			}
+		})
+	}
+}
+
+func TestRefreshSessionSkipsFreshSession(t *testing.T) {
+	service, store := newRefreshTestService(t, "puuid-fresh", map[string]string{"ssid": "fresh"})
+	session, _ := store.Get("s1")
+	session.TokenExpiresAt = time.Now().Add(time.Hour)
+	store.Save("s1", session)
+
+	refreshed, err := service.RefreshSession("s1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refreshed.AccessToken != "old-token" {
+		t.Errorf("令牌未过期时不应重新认证: %+v", refreshed)
+	}
+
+	if _, err := service.RefreshSession("missing"); !errors.Is(err, repositories.ErrSessionNotFound) {
+		t.Errorf("错误为%v，期望%v", err, repositories.ErrSessionNotFound)
+	}
+}
+
+func TestSessionLocksRetainSkipsHeldLocks(t *testing.T) {
+	var locks sessionLocks
+	locks.lock("live")()
+	locks.lock("removed")()
+	unlock := locks.lock("held")
+
+	locks.retain(map[string]*models.UserSession{"live": {}})
+
+	remaining := map[string]bool{}
+	locks.locks.Range(func(key, _ interface{}) bool {
+		remaining[key.(string)] = true
+		return true
+	})
+	if !remaining["live"] || !remaining["held"] || remaining["removed"] {
+		t.Errorf("剩余的锁为%v，期望live和held", remaining)
+	}
+
+	// 持有者解锁后下一次清理才删除
+	value, _ := locks.locks.Load("held")
+	unlock()
+	locks.retain(nil)
+	if _, ok := locks.locks.Load("held"); ok {
+		t.Error("解锁后的锁没有被删除")
+	}
+	if !value.(*sync.Mutex).TryLock() {
+		t.Error("删除锁时没有释放")
+	}
+}
@@ -0,0 +1,311 @@
+package services
+
+import (
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/emper0r/val-store-server/internal/models"
+	"github.com/emper0r/val-store-server/internal/repositories"
+)
+
+// StoreService 处理商店相关的业务逻辑
+type StoreService struct {
+	valorantAPI    *repositories.ValorantAPI
+	contentCatalog *repositories.ContentCatalog
+}
+
+// NewStoreService 创建新的商店服务
+func NewStoreService(valorantAPI *repositories.ValorantAPI, contentCatalog *repositories.ContentCatalog) *StoreService {
+	return &StoreService{
+		valorantAPI:    valorantAPI,
+		contentCatalog: contentCatalog,
+	}
+}
+
+// getStorefront 使用会话获取商店信息
+func (s *StoreService) getStorefront(session *models.UserSession) (*models.StorefrontResponse, error) {
+	client, err := s.valorantAPI.NewClient(session)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GetStorefront()
+}
+
+// newStoreItem 将Riot的商品报价转换为商店物品，并通过内容目录解析名称和图片
+// 商品中没有物品时返回false，调用方应跳过该商品
+func newStoreItem(offer models.StoreOffer, catalog *repositories.Catalog) (models.StoreItem, bool) {
+	if len(offer.Rewards) == 0 {
+		return models.StoreItem{}, false
+	}
+
+	reward := offer.Rewards[0]
+	info, _ := catalog.ResolveItem(reward.ItemID)
+	return models.StoreItem{
+		ItemInfo:   info,
+		OfferID:    offer.OfferID,
+		ItemID:     reward.ItemID,
+		ItemTypeID: reward.ItemTypeID,
+		Cost:       offer.Cost[models.CurrencyVP],
+	}, true
+}
+
+// GetDailyStore 获取每日商店的四个皮肤
+func (s *StoreService) GetDailyStore(session *models.UserSession, language string) (*models.DailyStoreResponse, error) {
+	storefront, err := s.getStorefront(session)
+	if err != nil {
+		return nil, err
+	}
+
+	return newDailyStore(storefront.SkinsPanelLayout, loadCatalog(s.contentCatalog, language)), nil
+}
+
+// newDailyStore 根据商店布局构建每日商店的响应
+func newDailyStore(layout models.SkinsPanelLayout, catalog *repositories.Catalog) *models.DailyStoreResponse {
+	response := &models.DailyStoreResponse{
+		Offers:           make([]models.StoreItem, 0, len(layout.SingleItemStoreOffers)),
+		RemainingSeconds: layout.SingleItemOffersRemainingDurationInSeconds,
+	}
+
+	for _, offer := range layout.SingleItemStoreOffers {
+		item, ok := newStoreItem(offer, catalog)
+		if !ok {
+			log.Printf("警告: 每日商店的商品 %s 中没有物品，已跳过", offer.OfferID)
+			continue
+		}
+		response.Offers = append(response.Offers, item)
+	}
+
+	return response
+}
+
+// GetNightMarket 获取夜市折扣商品，夜市未开放时返回Active为false的响应
+func (s *StoreService) GetNightMarket(session *models.UserSession, language string) (*models.NightMarketResponse, error) {
+	storefront, err := s.getStorefront(session)
+	if err != nil {
+		return nil, err
+	}
+
+	bonus := storefront.BonusStore
+	if bonus == nil || len(bonus.BonusStoreOffers) == 0 {
+		return &models.NightMarketResponse{
+			Active: false,
+			Offers: []models.NightMarketItem{},
+		}, nil
+	}
+
+	return newNightMarket(bonus, loadCatalog(s.contentCatalog, language)), nil
+}
+
+// newNightMarket 根据夜市数据构建夜市的响应
+func newNightMarket(bonus *models.BonusStore, catalog *repositories.Catalog) *models.NightMarketResponse {
+	response := &models.NightMarketResponse{
+		Active:           true,
+		Offers:           make([]models.NightMarketItem, 0, len(bonus.BonusStoreOffers)),
+		RemainingSeconds: bonus.BonusStoreRemainingDurationInSeconds,
+	}
+
+	for _, bonusOffer := range bonus.BonusStoreOffers {
+		item, ok := newStoreItem(bonusOffer.Offer, catalog)
+		if !ok {
+			log.Printf("警告: 夜市的商品 %s 中没有物品，已跳过", bonusOffer.Offer.OfferID)
+			continue
+		}
+
+		response.Offers = append(response.Offers, models.NightMarketItem{
+			StoreItem:        item,
+			DiscountedCost:   bonusOffer.DiscountCosts[models.CurrencyVP],
+			DiscountPercent:  bonusOffer.DiscountPercent,
+			Revealed:         bonusOffer.IsSeen,
+			RemainingSeconds: bonus.BonusStoreRemainingDurationInSeconds,
+		})
+	}
+
+	return response
+}
+
+// GetFeaturedBundles 获取精选捆绑包及其价格明细
+func (s *StoreService) GetFeaturedBundles(session *models.UserSession, language string) (*models.BundlesResponse, error) {
+	storefront, err := s.getStorefront(session)
+	if err != nil {
+		return nil, err
+	}
+
+	featured := storefront.FeaturedBundle
+	bundles := featured.Bundles
+	// 旧版响应只有单个Bundle字段
+	if len(bundles) == 0 && featured.Bundle.DataAssetID != "" {
+		bundles = []models.Bundle{featured.Bundle}
+	}
+
+	catalog := loadCatalog(s.contentCatalog, language)
+	response := &models.BundlesResponse{
+		Bundles: make([]models.BundleInfo, 0, len(bundles)),
+	}
+	for _, bundle := range bundles {
+		info := newBundleInfo(bundle, catalog)
+		if info.RemainingSeconds == 0 {
+			info.RemainingSeconds = featured.BundleRemainingDurationInSeconds
+		}
+		response.Bundles = append(response.Bundles, info)
+	}
+
+	return response, nil
+}
+
+// newBundleInfo 计算捆绑包的价格明细
+func newBundleInfo(bundle models.Bundle, catalog *repositories.Catalog) models.BundleInfo {
+	bundleInfo, _ := catalog.ResolveItem(bundle.DataAssetID)
+	info := models.BundleInfo{
+		ItemInfo:         bundleInfo,
+		BundleID:         bundle.DataAssetID,
+		CurrencyID:       bundle.CurrencyID,
+		Currency:         models.LookupCurrency(bundle.CurrencyID),
+		Items:            make([]models.BundleItem, 0, len(bundle.Items)),
+		WholesaleOnly:    bundle.WholesaleOnly,
+		RemainingSeconds: bundle.DurationRemainingInSeconds,
+	}
+
+	for _, offer := range bundle.Items {
+		itemInfo, _ := catalog.ResolveItem(offer.Item.ItemID)
+		info.Items = append(info.Items, models.BundleItem{
+			ItemInfo:        itemInfo,
+			ItemID:          offer.Item.ItemID,
+			ItemTypeID:      offer.Item.ItemTypeID,
+			Amount:          offer.Item.Amount,
+			BasePrice:       offer.BasePrice,
+			DiscountedPrice: offer.DiscountedPrice,
+			IsPromoItem:     offer.IsPromoItem,
+		})
+		info.BasePrice += offer.BasePrice
+		info.DiscountedPrice += offer.DiscountedPrice
+	}
+
+	// 优先使用Riot给出的总价，它已经包含整包折扣
+	if total, ok := bundle.TotalBaseCost[bundle.CurrencyID]; ok {
+		info.BasePrice = total
+	}
+	if total, ok := bundle.TotalDiscountedCost[bundle.CurrencyID]; ok {
+		info.DiscountedPrice = total
+	}
+	info.Savings = info.BasePrice - info.DiscountedPrice
+	if info.BasePrice > 0 {
+		info.DiscountPercent = float64(info.Savings) * 100 / float64(info.BasePrice)
+	}
+
+	return info
+}
+
+// GetAccessoryStore 获取配件商店的商品、王国信用点价格以及是否已经拥有
+func (s *StoreService) GetAccessoryStore(session *models.UserSession, language string) (*models.AccessoryStoreResponse, error) {
+	client, err := s.valorantAPI.NewClient(session)
+	if err != nil {
+		return nil, err
+	}
+
+	storefront, err := client.GetStorefront()
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := loadCatalog(s.contentCatalog, language)
+	store := storefront.AccessoryStore
+	response := &models.AccessoryStoreResponse{
+		Currency:         models.LookupCurrency(models.CurrencyKingdomCredits),
+		Offers:           make([]models.AccessoryItem, 0, len(store.AccessoryStoreOffers)),
+		RemainingSeconds: store.AccessoryStoreRemainingDurationInSeconds,
+	}
+
+	// 每种物品类型只查询一次权益
+	owned := make(map[string]map[string]bool)
+	for _, accessory := range store.AccessoryStoreOffers {
+		offer := accessory.Offer
+		if len(offer.Rewards) == 0 {
+			log.Printf("警告: 配件商店的商品 %s 中没有物品，已跳过", offer.OfferID)
+			continue
+		}
+		reward := offer.Rewards[0]
+
+		ownedItems, ok := owned[reward.ItemTypeID]
+		if !ok {
+			// 权益查询失败时该类型的物品都按未拥有返回，不影响其他商品
+			ownedItems = make(map[string]bool)
+			entitlements, err := client.GetEntitlements(reward.ItemTypeID)
+			if err != nil {
+				log.Printf("警告: 获取物品类型 %s 的权益失败，按未拥有处理: %v", reward.ItemTypeID, err)
+			} else {
+				for _, entitlement := range entitlements.Entitlements {
+					ownedItems[strings.ToLower(entitlement.ItemID)] = true
+				}
+			}
+			owned[reward.ItemTypeID] = ownedItems
+		}
+
+		info, _ := catalog.ResolveItem(reward.ItemID)
+		response.Offers = append(response.Offers, models.AccessoryItem{
+			ItemInfo:   info,
+			OfferID:    offer.OfferID,
+			ItemID:     reward.ItemID,
+			ItemTypeID: reward.ItemTypeID,
+			Type:       inventoryTypeName(reward.ItemTypeID),
+			Quantity:   reward.Quantity,
+			Cost:       offer.Cost[models.CurrencyKingdomCredits],
+			Owned:      ownedItems[strings.ToLower(reward.ItemID)],
+		})
+	}
+
+	return response, nil
+}
+
+// inventoryTypeName 返回物品类型ID在库存接口中对应的type，未知时返回空字符串
+func inventoryTypeName(itemTypeID string) string {
+	for name, id := range models.InventoryTypes {
+		if strings.EqualFold(id, itemTypeID) {
+			return name
+		}
+	}
+	return ""
+}
+
+// GetWallet 获取钱包余额，并将货币ID映射为具名货币
+func (s *StoreService) GetWallet(session *models.UserSession) (*models.WalletResponse, error) {
+	client, err := s.valorantAPI.NewClient(session)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := client.GetWallet()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.WalletResponse{
+		Balances: make([]models.CurrencyBalance, 0, len(wallet.Balances)),
+	}
+
+	// 已知货币按固定顺序返回，保证客户端显示稳定
+	for _, id := range []string{models.CurrencyVP, models.CurrencyRadianite, models.CurrencyKingdomCredits} {
+		response.Balances = append(response.Balances, models.CurrencyBalance{
+			Currency: models.LookupCurrency(id),
+			Amount:   wallet.Balances[id],
+		})
+	}
+
+	// 未知货币附加在后面
+	unknown := make([]string, 0)
+	for id := range wallet.Balances {
+		if _, ok := models.Currencies[id]; !ok {
+			unknown = append(unknown, id)
+		}
+	}
+	sort.Strings(unknown)
+	for _, id := range unknown {
+		response.Balances = append(response.Balances, models.CurrencyBalance{
+			Currency: models.LookupCurrency(id),
+			Amount:   wallet.Balances[id],
+		})
+	}
+
+	return response, nil
+}
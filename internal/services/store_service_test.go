@@ -0,0 +1,65 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/emper0r/val-store-server/internal/models"
+)
+
+// newTestOffer 创建价格为cost VP的单个皮肤报价，itemID为空时报价中没有物品
+func newTestOffer(offerID, itemID string, cost int) models.StoreOffer {
+	offer := models.StoreOffer{
+		OfferID: offerID,
+		Cost:    map[string]int{models.CurrencyVP: cost},
+	}
+	if itemID != "" {
+		offer.Rewards = []models.OfferReward{{ItemTypeID: "skin-level", ItemID: itemID, Quantity: 1}}
+	}
+	return offer
+}
+
+func TestNewDailyStoreSkipsEmptyOffers(t *testing.T) {
+	layout := models.SkinsPanelLayout{
+		SingleItemStoreOffers: []models.StoreOffer{
+			newTestOffer("offer-1", "skin-1", 1775),
+			newTestOffer("offer-empty", "", 0),
+			newTestOffer("offer-2", "skin-2", 875),
+		},
+		SingleItemOffersRemainingDurationInSeconds: 3600,
+	}
+
+	response := newDailyStore(layout, nil)
+
+	if response.RemainingSeconds != 3600 || len(response.Offers) != 2 {
+		t.Fatalf("每日商店为%+v，期望跳过空商品后剩下2个", response)
+	}
+	if response.Offers[0].ItemID != "skin-1" || response.Offers[0].Cost != 1775 || response.Offers[1].ItemID != "skin-2" {
+		t.Errorf("商品不正确: %+v", response.Offers)
+	}
+}
+
+func TestNewNightMarketSkipsEmptyOffers(t *testing.T) {
+	bonus := &models.BonusStore{
+		BonusStoreOffers: []models.BonusStoreOffer{
+			{Offer: newTestOffer("offer-empty", "", 0)},
+			{
+				Offer:           newTestOffer("offer-1", "skin-1", 1775),
+				DiscountPercent: 30,
+				DiscountCosts:   map[string]int{models.CurrencyVP: 1242},
+				IsSeen:          true,
+			},
+		},
+		BonusStoreRemainingDurationInSeconds: 7200,
+	}
+
+	response := newNightMarket(bonus, nil)
+
+	if !response.Active || response.RemainingSeconds != 7200 || len(response.Offers) != 1 {
+		t.Fatalf("夜市为%+v，期望跳过空商品后剩下1个", response)
+	}
+	offer := response.Offers[0]
+	if offer.ItemID != "skin-1" || offer.Cost != 1775 || offer.DiscountedCost != 1242 ||
+		offer.DiscountPercent != 30 || !offer.Revealed || offer.RemainingSeconds != 7200 {
+		t.Errorf("夜市商品不正确: %+v", offer)
+	}
+}